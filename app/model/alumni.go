@@ -17,6 +17,8 @@ type Alumni struct {
 	Email      string              `bson:"email" json:"email"`
 	NoTelepon  string              `bson:"no_telepon" json:"no_telepon"`
 	Alamat     string              `bson:"alamat" json:"alamat"`
+	FotoFileID *primitive.ObjectID `bson:"foto_file_id,omitempty" json:"foto_file_id,omitempty"` // foto profil di collection files
+	Foto       *FileResponse       `bson:"-" json:"foto,omitempty"`                              // diisi jika diminta dengan ?embed=file
 	IsDeleted  bool                `bson:"is_deleted" json:"is_deleted"`
 	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`
 	UpdatedAt  time.Time           `bson:"updated_at" json:"updated_at"`
@@ -46,3 +48,11 @@ type UpdateAlumniRequest struct {
 	NoTelepon  string `json:"no_telepon"`
 	Alamat     string `json:"alamat"`
 }
+
+// Filter untuk daftar alumni; field kosong / 0 berarti tidak difilter
+type AlumniFilter struct {
+	Search     string
+	Jurusan    string
+	Angkatan   int
+	TahunLulus int
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Aksi yang dicatat di audit log
+const (
+	AuditAksiHapusFile      = "hapus_file"
+	AuditAksiUbahKuota      = "ubah_kuota"
+	AuditAksiFileTerinfeksi = "file_terinfeksi"
+	AuditAksiRekonsiliasi   = "rekonsiliasi_file"
+	AuditAksiEksporFile     = "ekspor_file"
+)
+
+// Catatan aksi penting (collection "audit_log")
+type AuditLog struct {
+	ID        primitive.ObjectID     `bson:"_id,omitempty" json:"id,omitempty"`
+	Aksi      string                 `bson:"aksi" json:"aksi"`
+	Entitas   string                 `bson:"entitas" json:"entitas"` // nama collection yang terdampak
+	EntitasID string                 `bson:"entitas_id" json:"entitas_id"`
+	UserID    string                 `bson:"user_id" json:"user_id"` // pelaku
+	Username  string                 `bson:"username" json:"username"`
+	Role      string                 `bson:"role" json:"role"`
+	Detail    map[string]interface{} `bson:"detail,omitempty" json:"detail,omitempty"`
+	CreatedAt time.Time              `bson:"created_at" json:"created_at"`
+}
+
+// Filter daftar audit log
+type AuditFilter struct {
+	Aksi      string
+	Entitas   string
+	EntitasID string
+	Limit     int64
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Jenis dokumen. Setiap jenis menentukan kategori file yang boleh menjadi versinya.
+const (
+	JenisDokumenFotoProfil = "foto_profil"
+	JenisDokumenIjazah     = "ijazah"
+	JenisDokumenTranskrip  = "transkrip"
+	JenisDokumenSertifikat = "sertifikat"
+)
+
+var kategoriFileDokumen = map[string]string{
+	JenisDokumenFotoProfil: KategoriFileFoto,
+	JenisDokumenIjazah:     KategoriFileSertifikat,
+	JenisDokumenTranskrip:  KategoriFileSertifikat,
+	JenisDokumenSertifikat: KategoriFileSertifikat,
+}
+
+// KategoriFileDokumen mengembalikan kategori file untuk jenis dokumen, false jika jenis tidak dikenal
+func KategoriFileDokumen(jenis string) (string, bool) {
+	kategori, ok := kategoriFileDokumen[jenis]
+	return kategori, ok
+}
+
+// Dokumen adalah dokumen logis milik user (mis. foto profil, ijazah) yang menyimpan
+// riwayat versi file. CurrentFileID menunjuk versi yang sedang dipakai.
+type Dokumen struct {
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID        primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	Jenis         string              `bson:"jenis" json:"jenis"`
+	Nama          string              `bson:"nama" json:"nama"` // pembeda jika user punya beberapa dokumen dengan jenis sama
+	CurrentFileID *primitive.ObjectID `bson:"current_file_id,omitempty" json:"current_file_id,omitempty"`
+	LatestVersi   int                 `bson:"latest_versi" json:"latest_versi"`
+	Versions      []DokumenVersi      `bson:"versions" json:"versions"`
+	CreatedAt     time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time           `bson:"updated_at" json:"updated_at"`
+	Current       *FileResponse       `bson:"-" json:"current,omitempty"`
+}
+
+// DokumenVersi adalah satu file dalam riwayat dokumen, nomor versi terus bertambah
+type DokumenVersi struct {
+	Versi     int                `bson:"versi" json:"versi"`
+	FileID    primitive.ObjectID `bson:"file_id" json:"file_id"`
+	Catatan   string             `bson:"catatan,omitempty" json:"catatan,omitempty"`
+	CreatedBy string             `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	Current   bool               `bson:"-" json:"current"`
+	File      *FileResponse      `bson:"-" json:"file,omitempty"`
+}
+
+// Request membuat dokumen
+type CreateDokumenRequest struct {
+	Jenis  string `json:"jenis"`   // foto_profil, ijazah, transkrip atau sertifikat
+	Nama   string `json:"nama"`    // opsional
+	UserID string `json:"user_id"` // wajib untuk admin, diabaikan untuk user lain
+}
+
+// Request menambah versi baru dari file yang sudah di-upload
+type TambahVersiDokumenRequest struct {
+	FileID  string `json:"file_id"`
+	Catatan string `json:"catatan"`
+}
+
+// Request mengembalikan dokumen ke versi sebelumnya
+type RollbackDokumenRequest struct {
+	Versi int `json:"versi"`
+}
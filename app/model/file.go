@@ -6,23 +6,76 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Kategori upload, sekaligus nama folder di storage
+const (
+	KategoriFileFoto       = "foto"
+	KategoriFileSertifikat = "sertifikat"
+)
+
+// Status pemindaian malware. File pending / infected dikarantina: tidak bisa diunduh
+// maupun dipakai sebagai foto profil atau lampiran.
+const (
+	ScanStatusPending  = "pending"
+	ScanStatusClean    = "clean"
+	ScanStatusInfected = "infected"
+)
+
 type File struct {
-	ID           primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
-	UserID       *primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
-	FileName     string              `json:"file_name" bson:"file_name"`
-	OriginalName string              `json:"original_name" bson:"original_name"`
-	FilePath     string              `json:"file_path" bson:"file_path"`
-	FileSize     int64               `json:"file_size" bson:"file_size"`
-	FileType     string              `json:"file_type" bson:"file_type"`
-	UploadedAt   time.Time           `json:"uploaded_at" bson:"uploaded_at"`
+	ID             primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	UserID         *primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	FileName       string              `json:"file_name" bson:"file_name"`
+	OriginalName   string              `json:"original_name" bson:"original_name"`
+	StorageBackend string              `json:"storage_backend" bson:"storage_backend"`   // nama backend storage, mis. local atau s3
+	StorageKey     string              `json:"storage_key" bson:"storage_key"`           // key objek di backend storage
+	SHA256         string              `json:"sha256,omitempty" bson:"sha256,omitempty"` // hash isi file, kosong untuk file lama sebelum dedup
+	FileSize       int64               `json:"file_size" bson:"file_size"`
+	FileType       string              `json:"file_type" bson:"file_type"`
+	Kategori       string              `json:"kategori" bson:"kategori"`               // foto atau sertifikat
+	Width          int                 `json:"width,omitempty" bson:"width,omitempty"` // dimensi foto setelah diproses
+	Height         int                 `json:"height,omitempty" bson:"height,omitempty"`
+	Thumbnails     []FileThumbnail     `json:"thumbnails,omitempty" bson:"thumbnails,omitempty"`
+	ScanStatus     string              `json:"scan_status,omitempty" bson:"scan_status,omitempty"` // kosong untuk file lama sebelum ada pemindaian
+	ScanSignature  string              `json:"scan_signature,omitempty" bson:"scan_signature,omitempty"`
+	ScannedAt      *time.Time          `json:"scanned_at,omitempty" bson:"scanned_at,omitempty"`
+	MissingAt      *time.Time          `json:"missing_at,omitempty" bson:"missing_at,omitempty"` // diisi rekonsiliasi jika isi file tidak ada di storage
+	UploadedAt     time.Time           `json:"uploaded_at" bson:"uploaded_at"`
+}
+
+// Quarantined mengecek apakah file belum lolos pemindaian malware atau
+// ditandai hilang dari storage oleh rekonsiliasi
+func (f *File) Quarantined() bool {
+	return f.ScanStatus == ScanStatusPending || f.ScanStatus == ScanStatusInfected || f.MissingAt != nil
+}
+
+// FileThumbnail adalah versi kecil foto yang dibuat saat upload, disimpan di backend yang sama
+type FileThumbnail struct {
+	Size       string `json:"size" bson:"size"` // nama ukuran, mis. small, medium, large
+	StorageKey string `json:"storage_key" bson:"storage_key"`
+	FileSize   int64  `json:"file_size" bson:"file_size"`
+	Width      int    `json:"width" bson:"width"`
+	Height     int    `json:"height" bson:"height"`
 }
 
 type FileResponse struct {
-	ID           string    `json:"id"`
-	FileName     string    `json:"file_name"`
-	OriginalName string    `json:"original_name"`
-	FilePath     string    `json:"file_path"`
-	FileSize     int64     `json:"file_size"`
-	FileType     string    `json:"file_type"`
-	UploadedAt   time.Time `json:"uploaded_at"`
+	ID           string                  `json:"id"`
+	FileName     string                  `json:"file_name"`
+	OriginalName string                  `json:"original_name"`
+	FileSize     int64                   `json:"file_size"`
+	FileType     string                  `json:"file_type"`
+	SHA256       string                  `json:"sha256,omitempty"`
+	Kategori     string                  `json:"kategori"`
+	Width        int                     `json:"width,omitempty"`
+	Height       int                     `json:"height,omitempty"`
+	DownloadURL  string                  `json:"download_url"`
+	Thumbnails   []FileThumbnailResponse `json:"thumbnails,omitempty"`
+	ScanStatus   string                  `json:"scan_status,omitempty"`
+	UploadedAt   time.Time               `json:"uploaded_at"`
+}
+
+type FileThumbnailResponse struct {
+	Size        string `json:"size"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	FileSize    int64  `json:"file_size"`
+	DownloadURL string `json:"download_url"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// FileBlob adalah isi file yang disimpan sekali per hash SHA-256 (collection "file_blobs").
+// Beberapa File dengan isi yang sama menunjuk ke blob yang sama; objek di storage baru
+// dihapus saat ref_count habis.
+type FileBlob struct {
+	SHA256         string    `bson:"_id" json:"sha256"`
+	StorageBackend string    `bson:"storage_backend" json:"storage_backend"`
+	StorageKey     string    `bson:"storage_key" json:"storage_key"`
+	ThumbnailKeys  []string  `bson:"thumbnail_keys,omitempty" json:"thumbnail_keys,omitempty"`
+	Size           int64     `bson:"size" json:"size"`
+	ContentType    string    `bson:"content_type" json:"content_type"`
+	RefCount       int64     `bson:"ref_count" json:"ref_count"`
+	CreatedAt      time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// Level hirarki KBLI berdasarkan panjang kode
+const (
+	LevelIndustriKategori      = "kategori"       // huruf A-U
+	LevelIndustriGolonganPokok = "golongan_pokok" // 2 digit
+	LevelIndustriGolongan      = "golongan"       // 3 digit
+	LevelIndustriSubgolongan   = "subgolongan"    // 4 digit
+	LevelIndustriKelompok      = "kelompok"       // 5 digit
+)
+
+// Taksonomi bidang industri berbasis KBLI (collection "industri")
+type Industri struct {
+	Kode      string    `bson:"_id" json:"kode"`
+	Judul     string    `bson:"judul" json:"judul"`
+	Level     string    `bson:"level" json:"level"`
+	KodeInduk string    `bson:"kode_induk,omitempty" json:"kode_induk,omitempty"`
+	Path      []string  `bson:"path" json:"path"` // kode leluhur dari kategori sampai induk langsung
+	KataKunci []string  `bson:"kata_kunci" json:"kata_kunci,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Detail satu kode beserta leluhur dan turunan langsungnya
+type DetailIndustri struct {
+	Industri
+	Leluhur []Industri `json:"leluhur"`
+	Turunan []Industri `json:"turunan"`
+}
+
+// bidang_industri teks bebas pada pekerjaan yang belum punya kode_industri
+type BidangIndustriTanpaKode struct {
+	Nama            string `bson:"_id" json:"nama"`
+	JumlahPekerjaan int    `bson:"jumlah" json:"jumlah_pekerjaan"`
+}
+
+// Hasil pemetaan satu bidang_industri ke kode KBLI
+type HasilMapIndustri struct {
+	Nama            string `json:"nama"`
+	JumlahPekerjaan int    `json:"jumlah_pekerjaan"`
+	KodeIndustri    string `json:"kode_industri,omitempty"`
+	JudulIndustri   string `json:"judul_industri,omitempty"`
+}
+
+// LevelIndustri menentukan level KBLI dari bentuk kodenya
+func LevelIndustri(kode string) string {
+	switch len(kode) {
+	case 1:
+		return LevelIndustriKategori
+	case 2:
+		return LevelIndustriGolonganPokok
+	case 3:
+		return LevelIndustriGolongan
+	case 4:
+		return LevelIndustriSubgolongan
+	case 5:
+		return LevelIndustriKelompok
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Durasi dalam bulan kalender penuh dan jumlah hari
+type Durasi struct {
+	Bulan int `json:"bulan"`
+	Hari  int `json:"hari"`
+}
+
+// Satu pekerjaan pada timeline karir beserta masa kerjanya
+type KarirPekerjaan struct {
+	ID                  primitive.ObjectID `json:"id"`
+	NamaPerusahaan      string             `json:"nama_perusahaan"`
+	PosisiJabatan       string             `json:"posisi_jabatan"`
+	BidangIndustri      string             `json:"bidang_industri"`
+	LokasiKerja         string             `json:"lokasi_kerja"`
+	TanggalMulaiKerja   time.Time          `json:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *time.Time         `json:"tanggal_selesai_kerja,omitempty"`
+	StatusPekerjaan     string             `json:"status_pekerjaan"`
+	MasaKerja           Durasi             `json:"masa_kerja"`
+}
+
+// Periode tanpa pekerjaan di antara dua pekerjaan
+type JedaKarir struct {
+	Dari   time.Time `json:"dari"`
+	Sampai time.Time `json:"sampai"`
+	Durasi Durasi    `json:"durasi"`
+}
+
+// Response untuk endpoint /unair/alumni/:id/career
+type KarirAlumni struct {
+	AlumniID        primitive.ObjectID `json:"alumni_id"`
+	Nama            string             `json:"nama"`
+	TahunLulus      int                `json:"tahun_lulus"`
+	Pekerjaan       []KarirPekerjaan   `json:"pekerjaan"`
+	PosisiSaatIni   *KarirPekerjaan    `json:"posisi_saat_ini"`
+	TotalPengalaman Durasi             `json:"total_pengalaman"`
+	Jeda            []JedaKarir        `json:"jeda"`
+	// Waktu dari tanggal lulus (perkiraan, lihat utils.GraduationDate) ke pekerjaan pertama
+	MasaTungguPekerjaanPertama *Durasi `json:"masa_tunggu_pekerjaan_pertama"`
+	BekerjaSebelumLulus        bool    `json:"bekerja_sebelum_lulus"`
+}
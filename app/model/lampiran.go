@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Kategori lampiran pekerjaan
+const (
+	KategoriLampiranSuratKeterangan = "surat_keterangan_kerja"
+	KategoriLampiranKontrak         = "kontrak_kerja"
+	KategoriLampiranSertifikat      = "sertifikat"
+	KategoriLampiranLainnya         = "lainnya"
+)
+
+var kategoriLampiranValid = map[string]bool{
+	KategoriLampiranSuratKeterangan: true,
+	KategoriLampiranKontrak:         true,
+	KategoriLampiranSertifikat:      true,
+	KategoriLampiranLainnya:         true,
+}
+
+// IsKategoriLampiranValid mengecek kategori lampiran pekerjaan
+func IsKategoriLampiranValid(kategori string) bool {
+	return kategoriLampiranValid[kategori]
+}
+
+// LampiranPekerjaan menghubungkan file yang sudah di-upload dengan data pekerjaan
+type LampiranPekerjaan struct {
+	FileID     primitive.ObjectID `bson:"file_id" json:"file_id"`
+	Kategori   string             `bson:"kategori" json:"kategori"`
+	Keterangan string             `bson:"keterangan,omitempty" json:"keterangan,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	File       *FileResponse      `bson:"-" json:"file,omitempty"` // diisi jika diminta dengan ?embed=file
+}
+
+// Request menambah lampiran ke pekerjaan
+type TambahLampiranRequest struct {
+	FileID     string `json:"file_id"`
+	Kategori   string `json:"kategori"` // surat_keterangan_kerja, kontrak_kerja, sertifikat atau lainnya
+	Keterangan string `json:"keterangan"`
+}
+
+// Request mengatur foto profil alumni
+type SetFotoAlumniRequest struct {
+	FileID string `json:"file_id"`
+}
@@ -9,44 +9,57 @@ import (
 type Pekerjaan struct {
     ID                  primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
     AlumniID            primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
+    PerusahaanID        *primitive.ObjectID `bson:"perusahaan_id,omitempty" json:"perusahaan_id,omitempty"`
     NamaPerusahaan      string             `bson:"nama_perusahaan" json:"nama_perusahaan"`
     PosisiJabatan       string             `bson:"posisi_jabatan" json:"posisi_jabatan"`
     BidangIndustri      string             `bson:"bidang_industri" json:"bidang_industri"`
+    KodeIndustri        string             `bson:"kode_industri,omitempty" json:"kode_industri,omitempty"` // kode KBLI
     LokasiKerja         string             `bson:"lokasi_kerja" json:"lokasi_kerja"`
-    GajiRange           string             `bson:"gaji_range" json:"gaji_range"`
+    Gaji                `bson:",inline"`
+    GajiRange           string             `bson:"gaji_range,omitempty" json:"gaji_range,omitempty"` // teks gaji lama yang gagal di-parse migrasi, dihapus saat pekerjaan di-update
     TanggalMulaiKerja   time.Time          `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
     TanggalSelesaiKerja *time.Time         `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
     StatusPekerjaan     string             `bson:"status_pekerjaan" json:"status_pekerjaan"`
     DeskripsiPekerjaan  string             `bson:"deskripsi_pekerjaan" json:"deskripsi_pekerjaan"`
+    Lampiran            []LampiranPekerjaan `bson:"lampiran,omitempty" json:"lampiran,omitempty"`
     CreatedAt           time.Time          `bson:"created_at" json:"created_at"`
     UpdatedAt           time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
+// Informasi gaji terstruktur. GajiMax 0 berarti tanpa batas atas (mis. "> 10 juta")
+type Gaji struct {
+    GajiMin      int64  `bson:"gaji_min" json:"gaji_min"`
+    GajiMax      int64  `bson:"gaji_max" json:"gaji_max"`
+    GajiMataUang string `bson:"gaji_mata_uang" json:"gaji_mata_uang"` // kode ISO 4217, default IDR
+    GajiPeriode  string `bson:"gaji_periode" json:"gaji_periode"`     // bulanan atau tahunan
+}
+
+// Field pekerjaan yang diisi lewat request (create, update dan pengajuan alumni).
+// Tag bson dipakai saat data disimpan sebagai isi pengajuan_pekerjaan.
+type PekerjaanInput struct {
+    PerusahaanID        string `bson:"perusahaan_id,omitempty" json:"perusahaan_id"`   // opsional, jika kosong dicocokkan dari nama_perusahaan
+    NamaPerusahaan      string `bson:"nama_perusahaan" json:"nama_perusahaan"`
+    PosisiJabatan       string `bson:"posisi_jabatan" json:"posisi_jabatan"`
+    BidangIndustri      string `bson:"bidang_industri" json:"bidang_industri"`
+    KodeIndustri        string `bson:"kode_industri,omitempty" json:"kode_industri"`   // kode KBLI, bidang_industri diisi dari judulnya
+    LokasiKerja         string `bson:"lokasi_kerja" json:"lokasi_kerja"`
+    Gaji                `bson:",inline"`
+    GajiRange           string `bson:"-" json:"gaji_range"`                            // opsional, teks bebas (mis. "5-7 juta") jika gaji_min/gaji_max kosong
+    TanggalMulaiKerja   string `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"` // YYYY-MM-DD, RFC3339, DD-MM-YYYY atau DD/MM/YYYY
+    TanggalSelesaiKerja string `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja"`
+    StatusPekerjaan     string `bson:"status_pekerjaan" json:"status_pekerjaan"`
+    DeskripsiPekerjaan  string `bson:"deskripsi_pekerjaan" json:"deskripsi_pekerjaan"`
+}
+
 // Struktur untuk request membuat pekerjaan baru
 type CreatePekerjaanRequest struct {
     AlumniID            string `json:"alumni_id"`             // string dulu, nanti dikonversi ke ObjectID
-    NamaPerusahaan      string `json:"nama_perusahaan"`
-    PosisiJabatan       string `json:"posisi_jabatan"`
-    BidangIndustri      string `json:"bidang_industri"`
-    LokasiKerja         string `json:"lokasi_kerja"`
-    GajiRange           string `json:"gaji_range"`
-    TanggalMulaiKerja   string `json:"tanggal_mulai_kerja"`   // format ISO date
-    TanggalSelesaiKerja string `json:"tanggal_selesai_kerja"`
-    StatusPekerjaan     string `json:"status_pekerjaan"`
-    DeskripsiPekerjaan  string `json:"deskripsi_pekerjaan"`
+    PekerjaanInput
 }
 
 // Struktur untuk request update pekerjaan
 type UpdatePekerjaanRequest struct {
-    NamaPerusahaan      string `json:"nama_perusahaan"`
-    PosisiJabatan       string `json:"posisi_jabatan"`
-    BidangIndustri      string `json:"bidang_industri"`
-    LokasiKerja         string `json:"lokasi_kerja"`
-    GajiRange           string `json:"gaji_range"`
-    TanggalMulaiKerja   string `json:"tanggal_mulai_kerja"`
-    TanggalSelesaiKerja string `json:"tanggal_selesai_kerja"`
-    StatusPekerjaan     string `json:"status_pekerjaan"`
-    DeskripsiPekerjaan  string `json:"deskripsi_pekerjaan"`
+    PekerjaanInput
 }
 
 // Struktur jika mau join data alumni + pekerjaan (opsional)
@@ -60,22 +73,75 @@ type AlumniWithPekerjaan struct {
     BidangIndustri      string             `bson:"bidang_industri" json:"bidang_industri"`
     TanggalMulaiKerja   time.Time          `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
     TanggalSelesaiKerja *time.Time         `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
-    GajiRange           string             `bson:"gaji_range" json:"gaji_range"`
+    Gaji                `bson:",inline"`
 }
 
 type Trash struct {
     ID                  primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
     AlumniID            primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
+    PerusahaanID        *primitive.ObjectID `bson:"perusahaan_id,omitempty" json:"perusahaan_id,omitempty"`
     NamaPerusahaan      string             `bson:"nama_perusahaan" json:"nama_perusahaan"`
     PosisiJabatan       string             `bson:"posisi_jabatan" json:"posisi_jabatan"`
     BidangIndustri      string             `bson:"bidang_industri" json:"bidang_industri"`
+    KodeIndustri        string             `bson:"kode_industri,omitempty" json:"kode_industri,omitempty"` // kode KBLI
     LokasiKerja         string             `bson:"lokasi_kerja" json:"lokasi_kerja"`
-    GajiRange           string             `bson:"gaji_range" json:"gaji_range"`
+    Gaji                `bson:",inline"`
+    GajiRange           string             `bson:"gaji_range,omitempty" json:"gaji_range,omitempty"` // teks gaji lama yang gagal di-parse migrasi
     TanggalMulaiKerja   time.Time          `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
     TanggalSelesaiKerja *time.Time         `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
     StatusPekerjaan     string             `bson:"status_pekerjaan" json:"status_pekerjaan"`
     DeskripsiPekerjaan  string             `bson:"deskripsi_pekerjaan" json:"deskripsi_pekerjaan"`
+    Lampiran            []LampiranPekerjaan `bson:"lampiran,omitempty" json:"lampiran,omitempty"`
     IsDeleted           bool               `bson:"is_deleted" json:"is_deleted"`
     CreatedAt           time.Time          `bson:"created_at" json:"created_at"`
     UpdatedAt           time.Time          `bson:"updated_at" json:"updated_at"`
 }
+
+// Filter untuk daftar pekerjaan. Gaji dibandingkan setelah dikonversi ke Periode
+type PekerjaanFilter struct {
+    Search   string
+    Status   string
+    GajiMin  int64
+    GajiMax  int64
+    MataUang string
+    Periode  string
+}
+
+// Periode gaji yang diizinkan
+const (
+    GajiPeriodeBulanan = "bulanan"
+    GajiPeriodeTahunan = "tahunan"
+)
+
+// Status pekerjaan yang diizinkan
+const (
+    StatusPekerjaanAktif   = "aktif"
+    StatusPekerjaanCuti    = "cuti"
+    StatusPekerjaanSelesai = "selesai"
+)
+
+// Transisi status yang diizinkan: status asal -> status tujuan
+var transisiStatusPekerjaan = map[string][]string{
+    StatusPekerjaanAktif:   {StatusPekerjaanCuti, StatusPekerjaanSelesai},
+    StatusPekerjaanCuti:    {StatusPekerjaanAktif, StatusPekerjaanSelesai},
+    StatusPekerjaanSelesai: {},
+}
+
+// IsValidStatusPekerjaan mengecek apakah status termasuk enum yang dikenal
+func IsValidStatusPekerjaan(status string) bool {
+    _, ok := transisiStatusPekerjaan[status]
+    return ok
+}
+
+// CanTransitionStatusPekerjaan mengecek apakah perubahan status diizinkan
+func CanTransitionStatusPekerjaan(from, to string) bool {
+    if from == to {
+        return true
+    }
+    for _, next := range transisiStatusPekerjaan[from] {
+        if next == to {
+            return true
+        }
+    }
+    return false
+}
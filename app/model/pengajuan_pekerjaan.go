@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Jenis pengajuan pekerjaan oleh alumni
+const (
+	JenisPengajuanBaru = "baru" // pekerjaan baru
+	JenisPengajuanUbah = "ubah" // perubahan pekerjaan yang sudah ada
+)
+
+// Status moderasi pengajuan pekerjaan
+const (
+	StatusPengajuanPending   = "pending"
+	StatusPengajuanDisetujui = "disetujui"
+	StatusPengajuanDitolak   = "ditolak"
+)
+
+// Pengajuan pekerjaan baru / perubahan dari alumni (collection "pengajuan_pekerjaan").
+// Setelah disetujui admin, Data disimpan ke pekerjaan_alumni.
+type PengajuanPekerjaan struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty"`
+	AlumniID     primitive.ObjectID  `bson:"alumni_id" json:"alumni_id"`
+	PekerjaanID  *primitive.ObjectID `bson:"pekerjaan_id,omitempty" json:"pekerjaan_id,omitempty"` // pekerjaan yang diubah, atau hasil persetujuan pengajuan baru
+	Jenis        string              `bson:"jenis" json:"jenis"`
+	Data         PekerjaanInput      `bson:"data" json:"data"`
+	Status       string              `bson:"status" json:"status"`
+	Komentar     string              `bson:"komentar,omitempty" json:"komentar,omitempty"` // komentar admin saat review
+	DiajukanOleh string              `bson:"diajukan_oleh" json:"diajukan_oleh"`
+	DitinjauOleh string              `bson:"ditinjau_oleh,omitempty" json:"ditinjau_oleh,omitempty"`
+	DitinjauPada *time.Time          `bson:"ditinjau_pada,omitempty" json:"ditinjau_pada,omitempty"`
+	CreatedAt    time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+// Request alumni mengajukan pekerjaan; pekerjaan_id diisi untuk mengajukan perubahan
+type PengajuanPekerjaanRequest struct {
+	PekerjaanID string `json:"pekerjaan_id"`
+	PekerjaanInput
+}
+
+// Request admin menyetujui / menolak pengajuan
+type ReviewPengajuanRequest struct {
+	Komentar string `json:"komentar"`
+}
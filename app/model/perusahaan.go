@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Master data perusahaan (collection "companies")
+type Perusahaan struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Nama           string             `bson:"nama" json:"nama"` // nama kanonik
+	NamaNormal     string             `bson:"nama_normal" json:"-"`
+	Alias          []string           `bson:"alias" json:"alias"`
+	AliasNormal    []string           `bson:"alias_normal" json:"-"`
+	BidangIndustri string             `bson:"bidang_industri" json:"bidang_industri"`
+	Lokasi         string             `bson:"lokasi" json:"lokasi"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Request membuat / mengubah perusahaan
+type PerusahaanRequest struct {
+	Nama           string   `json:"nama"`
+	Alias          []string `json:"alias"`
+	BidangIndustri string   `json:"bidang_industri"`
+	Lokasi         string   `json:"lokasi"`
+}
+
+// Request menggabungkan perusahaan duplikat ke perusahaan tujuan
+type MergePerusahaanRequest struct {
+	SourceIDs []string `json:"source_ids"`
+}
+
+// Alumni yang pernah / sedang bekerja di sebuah perusahaan
+type AlumniPerusahaan struct {
+	PekerjaanID         primitive.ObjectID `bson:"_id" json:"pekerjaan_id"`
+	AlumniID            primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
+	Nama                string             `bson:"nama" json:"nama"`
+	Jurusan             string             `bson:"jurusan" json:"jurusan"`
+	Angkatan            int                `bson:"angkatan" json:"angkatan"`
+	PosisiJabatan       string             `bson:"posisi_jabatan" json:"posisi_jabatan"`
+	TanggalMulaiKerja   time.Time          `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *time.Time         `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
+	StatusPekerjaan     string             `bson:"status_pekerjaan" json:"status_pekerjaan"`
+}
+
+// nama_perusahaan pada pekerjaan yang belum terhubung ke master perusahaan
+type NamaPerusahaanTanpaLink struct {
+	Nama            string `bson:"_id" json:"nama"`
+	JumlahPekerjaan int    `bson:"jumlah" json:"jumlah_pekerjaan"`
+}
+
+// Hasil pencocokan satu nama_perusahaan
+type HasilMatchPerusahaan struct {
+	Nama            string             `json:"nama"`
+	JumlahPekerjaan int                `json:"jumlah_pekerjaan"`
+	PerusahaanID    primitive.ObjectID `json:"perusahaan_id,omitempty"`
+	PerusahaanNama  string             `json:"perusahaan_nama,omitempty"`
+	Saran           []SaranPerusahaan  `json:"saran,omitempty"`
+}
+
+// Kandidat perusahaan yang mirip untuk nama yang tidak cocok persis
+type SaranPerusahaan struct {
+	PerusahaanID primitive.ObjectID `json:"perusahaan_id"`
+	Nama         string             `json:"nama"`
+	Skor         float64            `json:"skor"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Tindakan rekonsiliasi file
+const (
+	ReconcileReport     = "report"     // hanya melaporkan
+	ReconcileQuarantine = "quarantine" // objek yatim dipindah ke quarantine/, file hilang ditandai missing_at
+	ReconcileDelete     = "delete"     // objek yatim dan metadata file hilang dihapus
+)
+
+// IsReconcileActionValid mengecek tindakan rekonsiliasi yang dikenal
+func IsReconcileActionValid(action string) bool {
+	return action == ReconcileReport || action == ReconcileQuarantine || action == ReconcileDelete
+}
+
+// OrphanObject adalah objek di storage yang tidak dirujuk dokumen files mana pun
+type OrphanObject struct {
+	Backend string    `json:"backend"`
+	Key     string    `json:"key"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Fixed   bool      `json:"fixed"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// MissingFile adalah dokumen files yang isinya tidak ada di storage
+type MissingFile struct {
+	FileID       string `json:"file_id"`
+	UserID       string `json:"user_id,omitempty"`
+	OriginalName string `json:"original_name"`
+	Backend      string `json:"backend"`
+	StorageKey   string `json:"storage_key"`
+	Fixed        bool   `json:"fixed"`
+	Error        string `json:"error,omitempty"`
+}
+
+// ReconcileResult adalah laporan satu kali rekonsiliasi storage dengan collection files
+type ReconcileResult struct {
+	Action         string         `json:"action"`
+	MinAgeMinutes  int            `json:"min_age_minutes"`
+	StartedAt      time.Time      `json:"started_at"`
+	FinishedAt     time.Time      `json:"finished_at"`
+	CheckedFiles   int            `json:"checked_files"`
+	ScannedObjects int            `json:"scanned_objects"`
+	OrphanObjects  []OrphanObject `json:"orphan_objects"`
+	MissingFiles   []MissingFile  `json:"missing_files"`
+	RestoredFiles  []string       `json:"restored_files,omitempty"` // file bertanda missing_at yang isinya sudah ada lagi
+	Errors         []string       `json:"errors,omitempty"`
+}
@@ -0,0 +1,40 @@
+package models
+
+// Keterserapan kerja alumni per kelompok (jurusan / angkatan / tahun_lulus)
+type StatistikKeterserapan struct {
+	Kelompok          interface{} `bson:"_id" json:"kelompok"`
+	TotalAlumni       int         `bson:"total_alumni" json:"total_alumni"`
+	Bekerja           int         `bson:"bekerja" json:"bekerja"`
+	BekerjaSaatIni    int         `bson:"bekerja_saat_ini" json:"bekerja_saat_ini"`
+	PersentaseBekerja float64     `bson:"-" json:"persentase_bekerja"`
+	PersentaseSaatIni float64     `bson:"-" json:"persentase_saat_ini"`
+}
+
+// Masa tunggu (bulan) dari kelulusan ke pekerjaan pertama per kelompok
+type StatistikMasaTunggu struct {
+	Kelompok           interface{} `bson:"_id" json:"kelompok"`
+	JumlahAlumni       int         `bson:"jumlah_alumni" json:"jumlah_alumni"`
+	RataRataBulan      float64     `bson:"rata_rata_bulan" json:"rata_rata_bulan"`
+	MinBulan           int         `bson:"min_bulan" json:"min_bulan"`
+	MaxBulan           int         `bson:"max_bulan" json:"max_bulan"`
+	MaksEnamBulan      int         `bson:"maks_enam_bulan" json:"maks_enam_bulan"`
+	PersentaseMaksEnam float64     `bson:"-" json:"persentase_maks_enam_bulan"`
+}
+
+// Distribusi pekerjaan berdasarkan satu field (bidang industri / lokasi kerja)
+type StatistikDistribusi struct {
+	Nama            string  `bson:"_id" json:"nama"`
+	Kode            string  `bson:"kode,omitempty" json:"kode,omitempty"` // kode KBLI untuk distribusi industri
+	JumlahPekerjaan int     `bson:"jumlah_pekerjaan" json:"jumlah_pekerjaan"`
+	JumlahAlumni    int     `bson:"jumlah_alumni" json:"jumlah_alumni"`
+	Persentase      float64 `bson:"-" json:"persentase"`
+}
+
+// Jumlah pekerjaan per rentang gaji bulanan (IDR)
+type StatistikGaji struct {
+	Band       string  `json:"band"`
+	BatasBawah int64   `json:"batas_bawah"`
+	BatasAtas  int64   `json:"batas_atas"` // 0 = tanpa batas atas
+	Jumlah     int     `json:"jumlah"`
+	Persentase float64 `json:"persentase"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Sumber kuota yang berlaku untuk user
+const (
+	QuotaSourceRole = "role"
+	QuotaSourceUser = "user"
+)
+
+// StorageQuota adalah kuota khusus satu user (collection "storage_quotas"),
+// menimpa kuota bawaan role-nya. Nilai 0 berarti tanpa batas.
+type StorageQuota struct {
+	UserID    primitive.ObjectID `bson:"_id" json:"user_id"`
+	MaxBytes  int64              `bson:"max_bytes" json:"max_bytes"`
+	MaxFiles  int64              `bson:"max_files" json:"max_files"`
+	Catatan   string             `bson:"catatan,omitempty" json:"catatan,omitempty"`
+	UpdatedBy string             `bson:"updated_by" json:"updated_by"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Request mengatur kuota khusus user
+type SetStorageQuotaRequest struct {
+	MaxBytes int64  `json:"max_bytes"` // 0 = tanpa batas
+	MaxFiles int64  `json:"max_files"` // 0 = tanpa batas
+	Catatan  string `json:"catatan"`
+}
+
+// StorageUsageCategory adalah pemakaian storage per kategori file
+type StorageUsageCategory struct {
+	Kategori  string `bson:"_id" json:"kategori"`
+	Bytes     int64  `bson:"bytes" json:"bytes"`
+	FileCount int64  `bson:"file_count" json:"file_count"`
+}
+
+// StorageUsage adalah pemakaian storage satu user beserta kuotanya
+type StorageUsage struct {
+	UserID      string                 `json:"user_id"`
+	UsedBytes   int64                  `json:"used_bytes"`
+	FileCount   int64                  `json:"file_count"`
+	MaxBytes    int64                  `json:"max_bytes"` // 0 = tanpa batas
+	MaxFiles    int64                  `json:"max_files"` // 0 = tanpa batas
+	QuotaSource string                 `json:"quota_source"`
+	PerKategori []StorageUsageCategory `json:"per_kategori"`
+}
+
+// StorageConsumer adalah satu baris laporan pemakaian storage terbesar
+type StorageConsumer struct {
+	UserID    primitive.ObjectID `bson:"_id" json:"user_id"`
+	Username  string             `bson:"username,omitempty" json:"username,omitempty"`
+	Role      string             `bson:"role,omitempty" json:"role,omitempty"`
+	Bytes     int64              `bson:"bytes" json:"bytes"`
+	FileCount int64              `bson:"file_count" json:"file_count"`
+}
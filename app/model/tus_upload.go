@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Versi protokol tus yang didukung
+const TusVersion = "1.0.0"
+
+// TusUpload adalah status upload resumable (tus). Potongan file disimpan di folder
+// sementara sampai Offset mencapai Length, lalu diproses seperti upload biasa.
+type TusUpload struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID  `bson:"user_id" json:"user_id"`       // pemilik file setelah selesai
+	CreatedBy string              `bson:"created_by" json:"created_by"` // user_id yang membuat upload, hanya dia yang boleh melanjutkan
+	Kategori  string              `bson:"kategori" json:"kategori"`     // foto atau sertifikat
+	FileName  string              `bson:"file_name" json:"file_name"`   // nama asli dari metadata filename
+	FileType  string              `bson:"file_type" json:"file_type"`   // content type yang dideklarasikan client
+	Length    int64               `bson:"length" json:"length"`
+	Offset    int64               `bson:"offset" json:"offset"`
+	FileID    *primitive.ObjectID `bson:"file_id,omitempty" json:"file_id,omitempty"` // diisi setelah upload selesai diproses
+	ExpiresAt time.Time           `bson:"expires_at" json:"expires_at"`
+	CreatedAt time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time           `bson:"updated_at" json:"updated_at"`
+}
@@ -3,8 +3,9 @@ package repository
 import (
 	"context"
 	"time"
-	"log"
+	"log/slog"
 	"fmt"
+	"regexp"
 
 	models "crud-app/app/model"
 
@@ -24,8 +25,11 @@ type AlumniRepository interface {
 	Restore(ctx context.Context, id string) error
 	GetWithoutPekerjaan(ctx context.Context) ([]models.Alumni, error)
 	CountWithoutPekerjaan(ctx context.Context) (int, error)
-	GetAlumniRepo(ctx context.Context, search, sortBy, order string, limit, offset int64) ([]models.Alumni, error)
-	CountAlumniRepo(ctx context.Context, search string) (int64, error)
+	GetAlumniRepo(ctx context.Context, filter models.AlumniFilter, sortBy, order string, limit, offset int64) ([]models.Alumni, error)
+	CountAlumniRepo(ctx context.Context, filter models.AlumniFilter) (int64, error)
+	SetFotoFile(ctx context.Context, id string, fileID *primitive.ObjectID) error
+	ClearFotoFile(ctx context.Context, fileID primitive.ObjectID) error
+	UsesFotoFile(ctx context.Context, fileID primitive.ObjectID) (bool, error)
 }
 
 // ================= STRUCT =================
@@ -67,7 +71,7 @@ func (r *alumniRepository) Create(ctx context.Context, req *models.CreateAlumniR
 		UpdatedAt:  time.Now(),
 	}
 
-	_, err := r.collection.InsertOne(ctx, alumni)
+	_, err := r.collection.InsertOne(ctx, alumni, insertComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +82,7 @@ func (r *alumniRepository) Create(ctx context.Context, req *models.CreateAlumniR
 // ================= GET ALL =================
 func (r *alumniRepository) GetAll(ctx context.Context) ([]models.Alumni, error) {
 	filter := bson.M{"is_deleted": false}
-	cursor, err := r.collection.Find(ctx, filter)
+	cursor, err := r.collection.Find(ctx, filter, findComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +103,7 @@ func (r *alumniRepository) GetByID(ctx context.Context, id string) (*models.Alum
 	}
 
 	var alumni models.Alumni
-	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "is_deleted": false}).Decode(&alumni)
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "is_deleted": false}, findOneComment(ctx)).Decode(&alumni)
 	if err == mongo.ErrNoDocuments {
 		return nil, nil
 	}
@@ -128,7 +132,7 @@ func (r *alumniRepository) Update(ctx context.Context, id string, req *models.Up
 		},
 	}
 
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update, updateComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +152,7 @@ func (r *alumniRepository) SoftDelete(ctx context.Context, id string) error {
 		"updated_at": time.Now(),
 	}}
 
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update, updateComment(ctx))
 	return err
 }
 
@@ -164,10 +168,45 @@ func (r *alumniRepository) Restore(ctx context.Context, id string) error {
 		"updated_at": time.Now(),
 	}}
 
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update, updateComment(ctx))
 	return err
 }
 
+// ================= FOTO PROFIL =================
+// SetFotoFile mengatur foto profil alumni; fileID nil berarti foto dihapus
+func (r *alumniRepository) SetFotoFile(ctx context.Context, id string, fileID *primitive.ObjectID) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"updated_at": time.Now()}}
+	if fileID != nil {
+		update["$set"].(bson.M)["foto_file_id"] = *fileID
+	} else {
+		update["$unset"] = bson.M{"foto_file_id": ""}
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update, updateComment(ctx))
+	return err
+}
+
+// ClearFotoFile menghapus referensi foto profil ke file yang dihapus (termasuk alumni yang di-soft delete)
+func (r *alumniRepository) ClearFotoFile(ctx context.Context, fileID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"foto_file_id": fileID},
+		bson.M{"$unset": bson.M{"foto_file_id": ""}, "$set": bson.M{"updated_at": time.Now()}},
+		updateComment(ctx),
+	)
+	return err
+}
+
+// UsesFotoFile mengecek apakah file masih menjadi foto profil alumni (termasuk yang di-soft delete)
+func (r *alumniRepository) UsesFotoFile(ctx context.Context, fileID primitive.ObjectID) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"foto_file_id": fileID}, countComment(ctx), options.Count().SetLimit(1))
+	return count > 0, err
+}
+
 // ================= GET WITHOUT PEKERJAAN =================
 func (r *alumniRepository) GetWithoutPekerjaan(ctx context.Context) ([]models.Alumni, error) {
 	pipeline := mongo.Pipeline{
@@ -183,7 +222,7 @@ func (r *alumniRepository) GetWithoutPekerjaan(ctx context.Context) ([]models.Al
 		}}},
 	}
 
-	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	cursor, err := r.collection.Aggregate(ctx, pipeline, aggregateComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +251,7 @@ func (r *alumniRepository) CountWithoutPekerjaan(ctx context.Context) (int, erro
 		{{Key: "$count", Value: "total"}},
 	}
 
-	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	cursor, err := r.collection.Aggregate(ctx, pipeline, aggregateComment(ctx))
 	if err != nil {
 		return 0, err
 	}
@@ -240,19 +279,7 @@ func (r *alumniRepository) CountWithoutPekerjaan(ctx context.Context) (int, erro
 }
 
 // ================= SEARCH + SORT + PAGINATION =================
-func (r *alumniRepository) GetAlumniRepo(ctx context.Context, search, sortBy, order string, limit, offset int64) ([]models.Alumni, error) {
-	filter := bson.M{
-		"is_deleted": false,
-		"$or": []bson.M{
-			{"nim": bson.M{"$regex": search, "$options": "i"}},
-			{"nama": bson.M{"$regex": search, "$options": "i"}},
-			{"jurusan": bson.M{"$regex": search, "$options": "i"}},
-			{"email": bson.M{"$regex": search, "$options": "i"}},
-			{"no_telepon": bson.M{"$regex": search, "$options": "i"}},
-			{"alamat": bson.M{"$regex": search, "$options": "i"}},
-		},
-	}
-
+func (r *alumniRepository) GetAlumniRepo(ctx context.Context, filter models.AlumniFilter, sortBy, order string, limit, offset int64) ([]models.Alumni, error) {
 	sortOrder := 1
 	if order == "desc" {
 		sortOrder = -1
@@ -263,9 +290,9 @@ func (r *alumniRepository) GetAlumniRepo(ctx context.Context, search, sortBy, or
 		SetLimit(limit).
 		SetSkip(offset)
 
-	cursor, err := r.collection.Find(ctx, filter, opts)
+	cursor, err := r.collection.Find(ctx, buildAlumniFilter(filter, ""), opts, findComment(ctx))
 	if err != nil {
-		log.Println("Query error:", err)
+		slog.ErrorContext(ctx, "Query alumni gagal", "err", err)
 		return nil, err
 	}
 	defer cursor.Close(ctx)
@@ -278,18 +305,31 @@ func (r *alumniRepository) GetAlumniRepo(ctx context.Context, search, sortBy, or
 }
 
 // ================= COUNT ALUMNI =================
-func (r *alumniRepository) CountAlumniRepo(ctx context.Context, search string) (int64, error) {
-	filter := bson.M{
-		"is_deleted": false,
-		"$or": []bson.M{
-			{"nim": bson.M{"$regex": search, "$options": "i"}},
-			{"nama": bson.M{"$regex": search, "$options": "i"}},
-			{"jurusan": bson.M{"$regex": search, "$options": "i"}},
-			{"email": bson.M{"$regex": search, "$options": "i"}},
-			{"no_telepon": bson.M{"$regex": search, "$options": "i"}},
-			{"alamat": bson.M{"$regex": search, "$options": "i"}},
-		},
-	}
-	count, err := r.collection.CountDocuments(ctx, filter)
+func (r *alumniRepository) CountAlumniRepo(ctx context.Context, filter models.AlumniFilter) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, buildAlumniFilter(filter, ""), countComment(ctx))
 	return count, err
 }
+
+// buildAlumniFilter menyusun filter MongoDB dari filter daftar alumni.
+// prefix dipakai saat dokumen alumni hasil $lookup berada di sub-field (mis. "alumni.").
+func buildAlumniFilter(f models.AlumniFilter, prefix string) bson.M {
+	filter := bson.M{prefix + "is_deleted": false}
+
+	if f.Search != "" {
+		var or []bson.M
+		for _, field := range []string{"nim", "nama", "jurusan", "email", "no_telepon", "alamat"} {
+			or = append(or, bson.M{prefix + field: bson.M{"$regex": f.Search, "$options": "i"}})
+		}
+		filter["$or"] = or
+	}
+	if f.Jurusan != "" {
+		filter[prefix+"jurusan"] = bson.M{"$regex": "^" + regexp.QuoteMeta(f.Jurusan) + "$", "$options": "i"}
+	}
+	if f.Angkatan > 0 {
+		filter[prefix+"angkatan"] = f.Angkatan
+	}
+	if f.TahunLulus > 0 {
+		filter[prefix+"tahun_lulus"] = f.TahunLulus
+	}
+	return filter
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditLog) error
+	GetAll(ctx context.Context, filter models.AuditFilter) ([]models.AuditLog, error)
+}
+
+type auditRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAuditRepository(database *mongo.Database) AuditRepository {
+	return &auditRepository{collection: database.Collection("audit_log")}
+}
+
+// ========================== CREATE ==========================
+func (r *auditRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	entry.CreatedAt = time.Now()
+	_, err := r.collection.InsertOne(ctx, entry, insertComment(ctx))
+	return err
+}
+
+// GetAll mengambil audit log terbaru lebih dulu
+func (r *auditRepository) GetAll(ctx context.Context, f models.AuditFilter) ([]models.AuditLog, error) {
+	filter := bson.M{}
+	if f.Aksi != "" {
+		filter["aksi"] = f.Aksi
+	}
+	if f.Entitas != "" {
+		filter["entitas"] = f.Entitas
+	}
+	if f.EntitasID != "" {
+		filter["entitas_id"] = f.EntitasID
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(f.Limit)
+	cursor, err := r.collection.Find(ctx, filter, opts, findComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []models.AuditLog{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
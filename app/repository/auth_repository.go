@@ -8,12 +8,14 @@ import (
 	models "crud-app/app/model"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // Interface AuthRepository mendefinisikan kontrak fungsi yang bisa digunakan oleh service
 type AuthRepository interface {
 	GetByUsername(ctx context.Context, username string) (*models.User, string, error)
+	GetByID(ctx context.Context, id primitive.ObjectID) (*models.User, error)
 }
 
 // Struktur utama repository
@@ -46,7 +48,7 @@ func (r *authRepository) GetByUsername(ctx context.Context, username string) (*m
 	}
 
 	// Ambil data dari MongoDB
-	err := r.collection.FindOne(ctx, filter).Decode(&user)
+	err := r.collection.FindOne(ctx, filter, findOneComment(ctx)).Decode(&user)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, "", fmt.Errorf("user tidak ditemukan")
@@ -62,3 +64,16 @@ func (r *authRepository) GetByUsername(ctx context.Context, username string) (*m
 
 	return &user, passwordHash, nil
 }
+
+// GetByID mencari user berdasarkan ID, nil jika tidak ditemukan
+func (r *authRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}, findOneComment(ctx)).Decode(&user)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+
+	"crud-app/utils"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Setiap query Mongo diberi comment berisi request ID, sehingga query di profiler, log
+// slow query atau currentOp bisa ditelusuri ke request-nya. Tanpa request ID (mis.
+// migration) option-nya nil dan diabaikan driver.
+
+func findComment(ctx context.Context) *options.FindOptions {
+	if id := utils.RequestID(ctx); id != "" {
+		return options.Find().SetComment(id)
+	}
+	return nil
+}
+
+func findOneComment(ctx context.Context) *options.FindOneOptions {
+	if id := utils.RequestID(ctx); id != "" {
+		return options.FindOne().SetComment(id)
+	}
+	return nil
+}
+
+func findOneAndUpdateComment(ctx context.Context) *options.FindOneAndUpdateOptions {
+	if id := utils.RequestID(ctx); id != "" {
+		return options.FindOneAndUpdate().SetComment(id)
+	}
+	return nil
+}
+
+func aggregateComment(ctx context.Context) *options.AggregateOptions {
+	if id := utils.RequestID(ctx); id != "" {
+		return options.Aggregate().SetComment(id)
+	}
+	return nil
+}
+
+func countComment(ctx context.Context) *options.CountOptions {
+	if id := utils.RequestID(ctx); id != "" {
+		return options.Count().SetComment(id)
+	}
+	return nil
+}
+
+func insertComment(ctx context.Context) *options.InsertOneOptions {
+	if id := utils.RequestID(ctx); id != "" {
+		return options.InsertOne().SetComment(id)
+	}
+	return nil
+}
+
+func updateComment(ctx context.Context) *options.UpdateOptions {
+	if id := utils.RequestID(ctx); id != "" {
+		return options.Update().SetComment(id)
+	}
+	return nil
+}
+
+func replaceComment(ctx context.Context) *options.ReplaceOptions {
+	if id := utils.RequestID(ctx); id != "" {
+		return options.Replace().SetComment(id)
+	}
+	return nil
+}
+
+func deleteComment(ctx context.Context) *options.DeleteOptions {
+	if id := utils.RequestID(ctx); id != "" {
+		return options.Delete().SetComment(id)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DokumenRepository interface {
+	Create(ctx context.Context, dokumen *models.Dokumen) (bool, error)
+	GetByID(ctx context.Context, id string) (*models.Dokumen, error)
+	List(ctx context.Context, userID *primitive.ObjectID) ([]models.Dokumen, error)
+	AddVersion(ctx context.Context, id primitive.ObjectID, expectedVersi int, versi models.DokumenVersi) (bool, error)
+	SetCurrent(ctx context.Context, id, fileID primitive.ObjectID) (bool, error)
+	RemoveVersions(ctx context.Context, id primitive.ObjectID, fileIDs []primitive.ObjectID) (bool, error)
+	RemoveFile(ctx context.Context, fileID primitive.ObjectID) error
+	UsesFile(ctx context.Context, fileID primitive.ObjectID) (bool, error)
+}
+
+type dokumenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDokumenRepository(database *mongo.Database) DokumenRepository {
+	return &dokumenRepository{collection: database.Collection("dokumen")}
+}
+
+// Create menyimpan dokumen baru. false jika user sudah punya dokumen dengan jenis dan nama yang sama.
+func (r *dokumenRepository) Create(ctx context.Context, dokumen *models.Dokumen) (bool, error) {
+	dokumen.ID = primitive.NewObjectID()
+	dokumen.CreatedAt = time.Now()
+	dokumen.UpdatedAt = dokumen.CreatedAt
+	if dokumen.Versions == nil {
+		dokumen.Versions = []models.DokumenVersi{}
+	}
+
+	_, err := r.collection.InsertOne(ctx, dokumen, insertComment(ctx))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetByID mengembalikan nil tanpa error jika dokumen tidak ditemukan atau id tidak valid
+func (r *dokumenRepository) GetByID(ctx context.Context, id string) (*models.Dokumen, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	var dokumen models.Dokumen
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}, findOneComment(ctx)).Decode(&dokumen)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &dokumen, nil
+}
+
+// List mengambil dokumen milik userID, atau semua dokumen jika userID nil
+func (r *dokumenRepository) List(ctx context.Context, userID *primitive.ObjectID) ([]models.Dokumen, error) {
+	filter := bson.M{}
+	if userID != nil {
+		filter["user_id"] = *userID
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "user_id", Value: 1}, {Key: "jenis", Value: 1}, {Key: "nama", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts, findComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []models.Dokumen{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// AddVersion menambah versi baru dan menjadikannya versi aktif. Hanya berhasil jika nomor
+// versi terakhir masih expectedVersi dan file belum menjadi versi dokumen ini; false jika tidak.
+func (r *dokumenRepository) AddVersion(ctx context.Context, id primitive.ObjectID, expectedVersi int, versi models.DokumenVersi) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "latest_versi": expectedVersi, "versions.file_id": bson.M{"$ne": versi.FileID}},
+		bson.M{
+			"$push": bson.M{"versions": versi},
+			"$set": bson.M{
+				"latest_versi":    versi.Versi,
+				"current_file_id": versi.FileID,
+				"updated_at":      time.Now(),
+			},
+		},
+		updateComment(ctx),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// SetCurrent memindah versi aktif ke fileID; false jika fileID bukan versi dokumen ini
+func (r *dokumenRepository) SetCurrent(ctx context.Context, id, fileID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "versions.file_id": fileID},
+		bson.M{"$set": bson.M{"current_file_id": fileID, "updated_at": time.Now()}},
+		updateComment(ctx),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RemoveVersions menghapus versi lama dari riwayat. false jika salah satunya sudah
+// menjadi versi aktif lagi (mis. karena rollback bersamaan) sehingga tidak ada yang dihapus.
+func (r *dokumenRepository) RemoveVersions(ctx context.Context, id primitive.ObjectID, fileIDs []primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "current_file_id": bson.M{"$nin": fileIDs}},
+		bson.M{
+			"$pull": bson.M{"versions": bson.M{"file_id": bson.M{"$in": fileIDs}}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+		updateComment(ctx),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RemoveFile melepas file yang dihapus dari semua riwayat dokumen. Jika file tersebut versi
+// aktif, versi aktif pindah ke versi terbaru yang tersisa.
+func (r *dokumenRepository) RemoveFile(ctx context.Context, fileID primitive.ObjectID) error {
+	if _, err := r.collection.UpdateMany(ctx,
+		bson.M{"versions.file_id": fileID},
+		bson.M{
+			"$pull": bson.M{"versions": bson.M{"file_id": fileID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+		updateComment(ctx),
+	); err != nil {
+		return err
+	}
+
+	// Versi disimpan berurutan, jadi versi terbaru yang tersisa adalah elemen terakhir
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"current_file_id": fileID},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"current_file_id": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$versions.file_id", -1}}, "$$REMOVE"}},
+				"updated_at":      time.Now(),
+			}}},
+		},
+		updateComment(ctx),
+	)
+	return err
+}
+
+// UsesFile mengecek apakah file masih menjadi versi di salah satu dokumen
+func (r *dokumenRepository) UsesFile(ctx context.Context, fileID primitive.ObjectID) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"versions.file_id": fileID}, countComment(ctx), options.Count().SetLimit(1))
+	return count > 0, err
+}
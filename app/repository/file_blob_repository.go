@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FileBlobRepository interface {
+	Acquire(ctx context.Context, blob *models.FileBlob) (*models.FileBlob, error)
+	Release(ctx context.Context, sha256 string) (*models.FileBlob, bool, error)
+}
+
+type fileBlobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFileBlobRepository(database *mongo.Database) FileBlobRepository {
+	return &fileBlobRepository{collection: database.Collection("file_blobs")}
+}
+
+// Acquire menambah satu referensi ke blob dengan hash yang sama, atau membuat blob baru
+// dari data blob jika belum ada. Blob yang tersimpan dikembalikan; RefCount 1 berarti
+// pemanggil adalah pemakai pertama dan harus menyimpan isinya ke storage.
+func (r *fileBlobRepository) Acquire(ctx context.Context, blob *models.FileBlob) (*models.FileBlob, error) {
+	now := time.Now()
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var stored models.FileBlob
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": blob.SHA256},
+		bson.M{
+			"$inc": bson.M{"ref_count": 1},
+			"$set": bson.M{"updated_at": now},
+			"$setOnInsert": bson.M{
+				"storage_backend": blob.StorageBackend,
+				"storage_key":     blob.StorageKey,
+				"thumbnail_keys":  blob.ThumbnailKeys,
+				"size":            blob.Size,
+				"content_type":    blob.ContentType,
+				"created_at":      now,
+			},
+		},
+		opts,
+		findOneAndUpdateComment(ctx),
+	).Decode(&stored)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// Release mengurangi satu referensi blob. Jika tidak ada lagi yang memakai, blob dihapus
+// dan dikembalikan dengan true agar pemanggil menghapus isinya dari storage. Acquire
+// setelah penghapusan membuat catatan baru dengan storage_key baru (lihat blobKey di
+// service), sehingga objek yang dihapus pemanggil tidak pernah dipakai upload baru.
+// Blob yang tidak ditemukan dikembalikan sebagai nil, false.
+func (r *fileBlobRepository) Release(ctx context.Context, sha256 string) (*models.FileBlob, bool, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var blob models.FileBlob
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": sha256, "ref_count": bson.M{"$gt": 0}},
+		bson.M{
+			"$inc": bson.M{"ref_count": -1},
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+		opts,
+		findOneAndUpdateComment(ctx),
+	).Decode(&blob)
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if blob.RefCount > 0 {
+		return &blob, false, nil
+	}
+
+	// Hanya dihapus jika belum ada upload baru yang mengambil referensi sejak dikurangi
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": sha256, "ref_count": bson.M{"$lte": 0}}, deleteComment(ctx))
+	if err != nil {
+		return nil, false, err
+	}
+	return &blob, result.DeletedCount > 0, nil
+}
@@ -8,14 +8,22 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type FileRepository interface {
-	Create(file *models.File) error
-	FindAll() ([]models.File, error)
-	FindByUserID(userID primitive.ObjectID) ([]models.File, error)
-	FindByID(id string) (*models.File, error)
-	Delete(id string) error
+	Create(ctx context.Context, file *models.File) error
+	FindAll(ctx context.Context) ([]models.File, error)
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.File, error)
+	FindByID(ctx context.Context, id string) (*models.File, error)
+	FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.File, error)
+	FindByUserIDs(ctx context.Context, userIDs []primitive.ObjectID) ([]models.File, error)
+	Delete(ctx context.Context, id string) error
+	UpdateScanStatus(ctx context.Context, id primitive.ObjectID, status, signature string) error
+	UpdateScanStatusBySHA256(ctx context.Context, sha256, status, signature string) error
+	SetMissing(ctx context.Context, id primitive.ObjectID, missingAt *time.Time) error
+	UsageByUser(ctx context.Context, userID primitive.ObjectID) ([]models.StorageUsageCategory, error)
+	TopUsage(ctx context.Context, limit int64) ([]models.StorageConsumer, error)
 }
 
 type fileRepository struct {
@@ -28,13 +36,10 @@ func NewFileRepository(db *mongo.Database) FileRepository {
 	}
 }
 
-func (r *fileRepository) Create(file *models.File) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
+func (r *fileRepository) Create(ctx context.Context, file *models.File) error {
 	file.UploadedAt = time.Now()
 
-	result, err := r.collection.InsertOne(ctx, file)
+	result, err := r.collection.InsertOne(ctx, file, insertComment(ctx))
 	if err != nil {
 		return err
 	}
@@ -43,12 +48,9 @@ func (r *fileRepository) Create(file *models.File) error {
 	return nil
 }
 
-func (r *fileRepository) FindAll() ([]models.File, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
+func (r *fileRepository) FindAll(ctx context.Context) ([]models.File, error) {
 	var files []models.File
-	cursor, err := r.collection.Find(ctx, bson.M{})
+	cursor, err := r.collection.Find(ctx, bson.M{}, findComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -60,12 +62,9 @@ func (r *fileRepository) FindAll() ([]models.File, error) {
 	return files, nil
 }
 
-func (r *fileRepository) FindByUserID(userID primitive.ObjectID) ([]models.File, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
+func (r *fileRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.File, error) {
 	var files []models.File
-	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, findComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -78,17 +77,14 @@ func (r *fileRepository) FindByUserID(userID primitive.ObjectID) ([]models.File,
 	return files, nil
 }
 
-func (r *fileRepository) FindByID(id string) (*models.File, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
+func (r *fileRepository) FindByID(ctx context.Context, id string) (*models.File, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, err
 	}
 
 	var file models.File
-	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&file)
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}, findOneComment(ctx)).Decode(&file)
 	if err != nil {
 		return nil, err
 	}
@@ -96,15 +92,158 @@ func (r *fileRepository) FindByID(id string) (*models.File, error) {
 	return &file, nil
 }
 
-func (r *fileRepository) Delete(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+func (r *fileRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.File, error) {
+	var files []models.File
+	if len(ids) == 0 {
+		return files, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, findComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (r *fileRepository) FindByUserIDs(ctx context.Context, userIDs []primitive.ObjectID) ([]models.File, error) {
+	var files []models.File
+	if len(userIDs) == 0 {
+		return files, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "uploaded_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": bson.M{"$in": userIDs}}, opts, findComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
 
+func (r *fileRepository) Delete(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID}, deleteComment(ctx))
 	return err
 }
+
+// UpdateScanStatus mencatat hasil pemindaian malware file
+func (r *fileRepository) UpdateScanStatus(ctx context.Context, id primitive.ObjectID, status, signature string) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"scan_status":    status,
+			"scan_signature": signature,
+			"scanned_at":     time.Now(),
+		}},
+		updateComment(ctx),
+	)
+	return err
+}
+
+// UpdateScanStatusBySHA256 mencatat hasil pemindaian untuk semua file dengan isi yang sama
+// (hasil dedup), karena semuanya memakai objek storage yang sama
+func (r *fileRepository) UpdateScanStatusBySHA256(ctx context.Context, sha256, status, signature string) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"sha256": sha256},
+		bson.M{"$set": bson.M{
+			"scan_status":    status,
+			"scan_signature": signature,
+			"scanned_at":     time.Now(),
+		}},
+		updateComment(ctx),
+	)
+	return err
+}
+
+// SetMissing menandai isi file hilang dari storage, atau menghapus tandanya jika missingAt nil
+func (r *fileRepository) SetMissing(ctx context.Context, id primitive.ObjectID, missingAt *time.Time) error {
+	update := bson.M{"$unset": bson.M{"missing_at": ""}}
+	if missingAt != nil {
+		update = bson.M{"$set": bson.M{"missing_at": *missingAt}}
+	}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update, updateComment(ctx))
+	return err
+}
+
+// storedBytes menghitung byte yang dipakai satu file di storage, termasuk thumbnail-nya
+var storedBytes = bson.M{"$add": bson.A{
+	"$file_size",
+	bson.M{"$sum": bson.M{"$ifNull": bson.A{"$thumbnails.file_size", bson.A{}}}},
+}}
+
+// UsageByUser menghitung pemakaian storage user per kategori file
+func (r *fileRepository) UsageByUser(ctx context.Context, userID primitive.ObjectID) ([]models.StorageUsageCategory, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$kategori",
+			"bytes":      bson.M{"$sum": storedBytes},
+			"file_count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline, aggregateComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	usage := []models.StorageUsageCategory{}
+	if err := cursor.All(ctx, &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// TopUsage mengambil user dengan pemakaian storage terbesar beserta username dan role-nya
+func (r *fileRepository) TopUsage(ctx context.Context, limit int64) ([]models.StorageConsumer, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": bson.M{"$ne": nil}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$user_id",
+			"bytes":      bson.M{"$sum": storedBytes},
+			"file_count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "bytes", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "users",
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "user",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$user", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$project", Value: bson.M{
+			"bytes":      1,
+			"file_count": 1,
+			"username":   "$user.username",
+			"role":       "$user.role",
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline, aggregateComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []models.StorageConsumer{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IndustriRepository interface {
+	GetAll(ctx context.Context) ([]models.Industri, error)
+	GetChildren(ctx context.Context, kodeInduk string) ([]models.Industri, error)
+	GetByKode(ctx context.Context, kode string) (*models.Industri, error)
+	GetByKodes(ctx context.Context, kodes []string) ([]models.Industri, error)
+	Search(ctx context.Context, q string, limit int64) ([]models.Industri, error)
+	GetUnmappedBidang(ctx context.Context) ([]models.BidangIndustriTanpaKode, error)
+	SetKodeIndustri(ctx context.Context, bidangIndustri []string, kode string) (int64, error)
+}
+
+type industriRepository struct {
+	collection          *mongo.Collection
+	pekerjaanCollection *mongo.Collection
+	trashCollection     *mongo.Collection
+}
+
+func NewIndustriRepository(database *mongo.Database) IndustriRepository {
+	return &industriRepository{
+		collection:          database.Collection("industri"),
+		pekerjaanCollection: database.Collection("pekerjaan_alumni"),
+		trashCollection:     database.Collection("trash_pekerjaan"),
+	}
+}
+
+var sortKodeIndustri = bson.D{{Key: "_id", Value: 1}}
+
+// ========================== GET ALL ==========================
+func (r *industriRepository) GetAll(ctx context.Context) ([]models.Industri, error) {
+	return r.find(ctx, bson.M{}, options.Find().SetSort(sortKodeIndustri))
+}
+
+// GetChildren mengambil turunan langsung sebuah kode; kode induk kosong berarti level kategori
+func (r *industriRepository) GetChildren(ctx context.Context, kodeInduk string) ([]models.Industri, error) {
+	filter := bson.M{"kode_induk": kodeInduk}
+	if kodeInduk == "" {
+		filter = bson.M{"level": models.LevelIndustriKategori}
+	}
+	return r.find(ctx, filter, options.Find().SetSort(sortKodeIndustri))
+}
+
+// ========================== GET BY KODE ==========================
+func (r *industriRepository) GetByKode(ctx context.Context, kode string) (*models.Industri, error) {
+	var industri models.Industri
+	err := r.collection.FindOne(ctx, bson.M{"_id": strings.ToUpper(strings.TrimSpace(kode))}, findOneComment(ctx)).Decode(&industri)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &industri, nil
+}
+
+func (r *industriRepository) GetByKodes(ctx context.Context, kodes []string) ([]models.Industri, error) {
+	if len(kodes) == 0 {
+		return []models.Industri{}, nil
+	}
+	return r.find(ctx, bson.M{"_id": bson.M{"$in": kodes}}, options.Find().SetSort(sortKodeIndustri))
+}
+
+// Search untuk autocomplete: awalan kode, judul atau kata kunci yang mengandung q.
+// Level yang lebih umum (kode lebih pendek) didahulukan.
+func (r *industriRepository) Search(ctx context.Context, q string, limit int64) ([]models.Industri, error) {
+	q = strings.TrimSpace(q)
+	pattern := bson.M{"$regex": regexp.QuoteMeta(q), "$options": "i"}
+	filter := bson.M{"$or": []bson.M{
+		{"_id": bson.M{"$regex": "^" + regexp.QuoteMeta(strings.ToUpper(q))}},
+		{"judul": pattern},
+		{"kata_kunci": pattern},
+	}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"panjang_kode": bson.M{"$strLenCP": "$_id"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "panjang_kode", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline, aggregateComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []models.Industri{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetUnmappedBidang mengambil bidang_industri unik dari pekerjaan yang belum punya kode_industri
+func (r *industriRepository) GetUnmappedBidang(ctx context.Context) ([]models.BidangIndustriTanpaKode, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"kode_industri": nil, "bidang_industri": bson.M{"$nin": bson.A{nil, ""}}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$bidang_industri", "jumlah": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "jumlah", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := r.pekerjaanCollection.Aggregate(ctx, pipeline, aggregateComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []models.BidangIndustriTanpaKode
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// SetKodeIndustri mengisi kode_industri pada pekerjaan (termasuk di trash) dengan bidang_industri tertentu.
+// bidang_industri teks asli tetap disimpan.
+func (r *industriRepository) SetKodeIndustri(ctx context.Context, bidangIndustri []string, kode string) (int64, error) {
+	filter := bson.M{"bidang_industri": bson.M{"$in": bidangIndustri}, "kode_industri": nil}
+	update := bson.M{"$set": bson.M{"kode_industri": kode}}
+
+	result, err := r.pekerjaanCollection.UpdateMany(ctx, filter, update, updateComment(ctx))
+	if err != nil {
+		return 0, err
+	}
+	if _, err := r.trashCollection.UpdateMany(ctx, filter, update, updateComment(ctx)); err != nil {
+		return result.ModifiedCount, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *industriRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.Industri, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts, findComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []models.Industri{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
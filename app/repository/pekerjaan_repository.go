@@ -3,10 +3,11 @@ package repository
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	models "crud-app/app/model"
+	"crud-app/utils"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -18,6 +19,7 @@ type PekerjaanRepository interface {
 	GetAll(ctx context.Context) ([]models.Pekerjaan, error)
 	GetByID(ctx context.Context, id string) (*models.Pekerjaan, error)
 	GetByAlumniID(ctx context.Context, alumniID string) ([]models.Pekerjaan, error)
+	GetByAlumniIDs(ctx context.Context, alumniIDs []primitive.ObjectID) ([]models.Pekerjaan, error)
 	Create(ctx context.Context, req *models.CreatePekerjaanRequest) (*models.Pekerjaan, error)
 	Update(ctx context.Context, id string, req *models.UpdatePekerjaanRequest) (*models.Pekerjaan, error)
 	SoftDeleteByID(ctx context.Context, id string) error
@@ -26,8 +28,12 @@ type PekerjaanRepository interface {
 	GetTrash(ctx context.Context) ([]models.Trash, error)
 	GetTrashByOwner(ctx context.Context, alumniID string) ([]models.Trash, error)
 	Delete(ctx context.Context, id string, alumniID *string) error
-	GetPekerjaanRepo(ctx context.Context, search, sortBy, order string, limit, offset int64) ([]models.Pekerjaan, error)
-	CountPekerjaanRepo(ctx context.Context, search string) (int64, error)
+	GetPekerjaanRepo(ctx context.Context, filter models.PekerjaanFilter, sortBy, order string, limit, offset int64) ([]models.Pekerjaan, error)
+	CountPekerjaanRepo(ctx context.Context, filter models.PekerjaanFilter) (int64, error)
+	AddLampiran(ctx context.Context, id primitive.ObjectID, lampiran models.LampiranPekerjaan) (bool, error)
+	RemoveLampiran(ctx context.Context, id, fileID primitive.ObjectID) (bool, error)
+	RemoveLampiranFile(ctx context.Context, fileID primitive.ObjectID) error
+	UsesLampiranFile(ctx context.Context, fileID primitive.ObjectID) (bool, error)
 }
 
 type pekerjaanRepository struct {
@@ -49,28 +55,35 @@ func (r *pekerjaanRepository) Create(ctx context.Context, req *models.CreatePeke
 		return nil, fmt.Errorf("alumni_id tidak valid: %v", err)
 	}
 
-	tglMulai, err := time.Parse(time.RFC3339, req.TanggalMulaiKerja)
+	tglMulai, err := utils.ParseDate(req.TanggalMulaiKerja)
 	if err != nil {
 		return nil, fmt.Errorf("format tanggal_mulai_kerja tidak valid")
 	}
 
 	var tglSelesaiPtr *time.Time
 	if req.TanggalSelesaiKerja != "" {
-		tglSelesai, err := time.Parse(time.RFC3339, req.TanggalSelesaiKerja)
+		tglSelesai, err := utils.ParseDate(req.TanggalSelesaiKerja)
 		if err != nil {
 			return nil, fmt.Errorf("format tanggal_selesai_kerja tidak valid")
 		}
 		tglSelesaiPtr = &tglSelesai
 	}
 
+	perusahaanObjID, err := parseOptionalObjectID(req.PerusahaanID)
+	if err != nil {
+		return nil, fmt.Errorf("perusahaan_id tidak valid: %v", err)
+	}
+
 	newPekerjaan := models.Pekerjaan{
 		ID:                  primitive.NewObjectID(),
 		AlumniID:            alumniObjID,
+		PerusahaanID:        perusahaanObjID,
 		NamaPerusahaan:      req.NamaPerusahaan,
 		PosisiJabatan:       req.PosisiJabatan,
 		BidangIndustri:      req.BidangIndustri,
+		KodeIndustri:        req.KodeIndustri,
 		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
+		Gaji:                req.Gaji,
 		TanggalMulaiKerja:   tglMulai,
 		TanggalSelesaiKerja: tglSelesaiPtr,
 		StatusPekerjaan:     req.StatusPekerjaan,
@@ -79,7 +92,7 @@ func (r *pekerjaanRepository) Create(ctx context.Context, req *models.CreatePeke
 		UpdatedAt:           time.Now(),
 	}
 
-	_, err = r.collection.InsertOne(ctx, newPekerjaan)
+	_, err = r.collection.InsertOne(ctx, newPekerjaan, insertComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +101,7 @@ func (r *pekerjaanRepository) Create(ctx context.Context, req *models.CreatePeke
 
 // ========================== GET ALL ==========================
 func (r *pekerjaanRepository) GetAll(ctx context.Context) ([]models.Pekerjaan, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{})
+	cursor, err := r.collection.Find(ctx, bson.M{}, findComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -109,13 +122,34 @@ func (r *pekerjaanRepository) GetByID(ctx context.Context, id string) (*models.P
 	}
 
 	var pekerjaan models.Pekerjaan
-	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&pekerjaan)
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}, findOneComment(ctx)).Decode(&pekerjaan)
 	if err == mongo.ErrNoDocuments {
 		return nil, nil
 	}
 	return &pekerjaan, err
 }
 
+// ========================== GET BY ALUMNI (BANYAK) ==========================
+// GetByAlumniIDs mengambil pekerjaan beberapa alumni sekaligus dalam satu query
+func (r *pekerjaanRepository) GetByAlumniIDs(ctx context.Context, alumniIDs []primitive.ObjectID) ([]models.Pekerjaan, error) {
+	var list []models.Pekerjaan
+	if len(alumniIDs) == 0 {
+		return list, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "tanggal_mulai_kerja", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"alumni_id": bson.M{"$in": alumniIDs}}, opts, findComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
 // ========================== GET BY ALUMNI ==========================
 func (r *pekerjaanRepository) GetByAlumniID(ctx context.Context, alumniID string) ([]models.Pekerjaan, error) {
 	alumniObjID, err := primitive.ObjectIDFromHex(alumniID)
@@ -123,7 +157,8 @@ func (r *pekerjaanRepository) GetByAlumniID(ctx context.Context, alumniID string
 		return nil, err
 	}
 
-	cursor, err := r.collection.Find(ctx, bson.M{"alumni_id": alumniObjID})
+	opts := options.Find().SetSort(bson.D{{Key: "tanggal_mulai_kerja", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"alumni_id": alumniObjID}, opts, findComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -143,58 +178,153 @@ func (r *pekerjaanRepository) Update(ctx context.Context, id string, req *models
 		return nil, err
 	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"nama_perusahaan":       req.NamaPerusahaan,
-			"posisi_jabatan":        req.PosisiJabatan,
-			"bidang_industri":       req.BidangIndustri,
-			"lokasi_kerja":          req.LokasiKerja,
-			"gaji_range":            req.GajiRange,
-			"tanggal_mulai_kerja":   req.TanggalMulaiKerja,
-			"tanggal_selesai_kerja": req.TanggalSelesaiKerja,
-			"status_pekerjaan":      req.StatusPekerjaan,
-			"deskripsi_pekerjaan":   req.DeskripsiPekerjaan,
-			"updated_at":            time.Now(),
-		},
+	set := bson.M{
+		"nama_perusahaan":     req.NamaPerusahaan,
+		"posisi_jabatan":      req.PosisiJabatan,
+		"bidang_industri":     req.BidangIndustri,
+		"lokasi_kerja":        req.LokasiKerja,
+		"gaji_min":            req.GajiMin,
+		"gaji_max":            req.GajiMax,
+		"gaji_mata_uang":      req.GajiMataUang,
+		"gaji_periode":        req.GajiPeriode,
+		"status_pekerjaan":    req.StatusPekerjaan,
+		"deskripsi_pekerjaan": req.DeskripsiPekerjaan,
+		"updated_at":          time.Now(),
 	}
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+
+	// Tanggal kosong berarti tidak diubah, selalu disimpan sebagai date
+	if req.TanggalMulaiKerja != "" {
+		tglMulai, err := utils.ParseDate(req.TanggalMulaiKerja)
+		if err != nil {
+			return nil, fmt.Errorf("format tanggal_mulai_kerja tidak valid")
+		}
+		set["tanggal_mulai_kerja"] = tglMulai
+	}
+	if req.TanggalSelesaiKerja != "" {
+		tglSelesai, err := utils.ParseDate(req.TanggalSelesaiKerja)
+		if err != nil {
+			return nil, fmt.Errorf("format tanggal_selesai_kerja tidak valid")
+		}
+		set["tanggal_selesai_kerja"] = tglSelesai
+	}
+
+	unset := bson.M{"gaji_range": ""}
+	perusahaanObjID, err := parseOptionalObjectID(req.PerusahaanID)
+	if err != nil {
+		return nil, fmt.Errorf("perusahaan_id tidak valid: %v", err)
+	}
+	if perusahaanObjID != nil {
+		set["perusahaan_id"] = perusahaanObjID
+	} else {
+		unset["perusahaan_id"] = ""
+	}
+	if req.KodeIndustri != "" {
+		set["kode_industri"] = req.KodeIndustri
+	} else {
+		unset["kode_industri"] = ""
+	}
+
+	update := bson.M{"$set": set, "$unset": unset}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update, updateComment(ctx))
 	if err != nil {
 		return nil, err
 	}
 	return r.GetByID(ctx, id)
 }
 
+// ========================== LAMPIRAN ==========================
+// AddLampiran menambah lampiran ke pekerjaan. false jika file sudah menjadi lampiran pekerjaan tersebut.
+func (r *pekerjaanRepository) AddLampiran(ctx context.Context, id primitive.ObjectID, lampiran models.LampiranPekerjaan) (bool, error) {
+	lampiran.CreatedAt = time.Now()
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "lampiran.file_id": bson.M{"$ne": lampiran.FileID}},
+		bson.M{
+			"$push": bson.M{"lampiran": lampiran},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+		updateComment(ctx),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// RemoveLampiran melepas satu file dari lampiran pekerjaan. false jika lampiran tidak ditemukan.
+func (r *pekerjaanRepository) RemoveLampiran(ctx context.Context, id, fileID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "lampiran.file_id": fileID},
+		bson.M{
+			"$pull": bson.M{"lampiran": bson.M{"file_id": fileID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+		updateComment(ctx),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// RemoveLampiranFile melepas file yang dihapus dari semua pekerjaan, termasuk yang ada di trash
+func (r *pekerjaanRepository) RemoveLampiranFile(ctx context.Context, fileID primitive.ObjectID) error {
+	filter := bson.M{"lampiran.file_id": fileID}
+	update := bson.M{"$pull": bson.M{"lampiran": bson.M{"file_id": fileID}}}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update, updateComment(ctx)); err != nil {
+		return err
+	}
+	_, err := r.trashCollection.UpdateMany(ctx, filter, update, updateComment(ctx))
+	return err
+}
+
+// UsesLampiranFile mengecek apakah file masih menjadi lampiran pekerjaan, termasuk yang ada di trash
+func (r *pekerjaanRepository) UsesLampiranFile(ctx context.Context, fileID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"lampiran.file_id": fileID}
+	for _, coll := range []*mongo.Collection{r.collection, r.trashCollection} {
+		count, err := coll.CountDocuments(ctx, filter, countComment(ctx), options.Count().SetLimit(1))
+		if err != nil || count > 0 {
+			return count > 0, err
+		}
+	}
+	return false, nil
+}
+
 // ========================== SOFT DELETE ==========================
 func (r *pekerjaanRepository) SoftDeleteByID(ctx context.Context, id string) error {
 	objID, _ := primitive.ObjectIDFromHex(id)
 
 	var pekerjaan models.Pekerjaan
-	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&pekerjaan); err != nil {
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}, findOneComment(ctx)).Decode(&pekerjaan); err != nil {
 		return fmt.Errorf("data tidak ditemukan")
 	}
 
 	trash := models.Trash{
 		ID:                  pekerjaan.ID,
 		AlumniID:            pekerjaan.AlumniID,
+		PerusahaanID:        pekerjaan.PerusahaanID,
 		NamaPerusahaan:      pekerjaan.NamaPerusahaan,
 		PosisiJabatan:       pekerjaan.PosisiJabatan,
 		BidangIndustri:      pekerjaan.BidangIndustri,
+		KodeIndustri:        pekerjaan.KodeIndustri,
 		LokasiKerja:         pekerjaan.LokasiKerja,
+		Gaji:                pekerjaan.Gaji,
 		GajiRange:           pekerjaan.GajiRange,
 		TanggalMulaiKerja:   pekerjaan.TanggalMulaiKerja,
 		TanggalSelesaiKerja: pekerjaan.TanggalSelesaiKerja,
 		StatusPekerjaan:     pekerjaan.StatusPekerjaan,
 		DeskripsiPekerjaan:  pekerjaan.DeskripsiPekerjaan,
+		Lampiran:            pekerjaan.Lampiran,
 		IsDeleted:           true,
 		CreatedAt:           pekerjaan.CreatedAt,
 		UpdatedAt:           time.Now(),
 	}
 
-	if _, err := r.trashCollection.InsertOne(ctx, trash); err != nil {
+	if _, err := r.trashCollection.InsertOne(ctx, trash, insertComment(ctx)); err != nil {
 		return err
 	}
 
-	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID}, deleteComment(ctx))
 	return err
 }
 
@@ -203,32 +333,36 @@ func (r *pekerjaanRepository) SoftDeleteByOwner(ctx context.Context, id, alumniI
 	alumniObj, _ := primitive.ObjectIDFromHex(alumniID)
 
 	var pekerjaan models.Pekerjaan
-	if err := r.collection.FindOne(ctx, bson.M{"_id": objID, "alumni_id": alumniObj}).Decode(&pekerjaan); err != nil {
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID, "alumni_id": alumniObj}, findOneComment(ctx)).Decode(&pekerjaan); err != nil {
 		return fmt.Errorf("data tidak ditemukan atau bukan milik user ini")
 	}
 
 	trash := models.Trash{
 		ID:                  pekerjaan.ID,
 		AlumniID:            pekerjaan.AlumniID,
+		PerusahaanID:        pekerjaan.PerusahaanID,
 		NamaPerusahaan:      pekerjaan.NamaPerusahaan,
 		PosisiJabatan:       pekerjaan.PosisiJabatan,
 		BidangIndustri:      pekerjaan.BidangIndustri,
+		KodeIndustri:        pekerjaan.KodeIndustri,
 		LokasiKerja:         pekerjaan.LokasiKerja,
+		Gaji:                pekerjaan.Gaji,
 		GajiRange:           pekerjaan.GajiRange,
 		TanggalMulaiKerja:   pekerjaan.TanggalMulaiKerja,
 		TanggalSelesaiKerja: pekerjaan.TanggalSelesaiKerja,
 		StatusPekerjaan:     pekerjaan.StatusPekerjaan,
 		DeskripsiPekerjaan:  pekerjaan.DeskripsiPekerjaan,
+		Lampiran:            pekerjaan.Lampiran,
 		IsDeleted:           true,
 		CreatedAt:           pekerjaan.CreatedAt,
 		UpdatedAt:           time.Now(),
 	}
 
-	if _, err := r.trashCollection.InsertOne(ctx, trash); err != nil {
+	if _, err := r.trashCollection.InsertOne(ctx, trash, insertComment(ctx)); err != nil {
 		return err
 	}
 
-	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID, "alumni_id": alumniObj})
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID, "alumni_id": alumniObj}, deleteComment(ctx))
 	return err
 }
 
@@ -245,37 +379,41 @@ func (r *pekerjaanRepository) Restore(ctx context.Context, id string, alumniID *
 	}
 
 	var trash models.Trash
-	if err := r.trashCollection.FindOne(ctx, filter).Decode(&trash); err != nil {
+	if err := r.trashCollection.FindOne(ctx, filter, findOneComment(ctx)).Decode(&trash); err != nil {
 		return fmt.Errorf("data tidak ditemukan di trash atau kamu tidak punya akses")
 	}
 
 	pekerjaan := models.Pekerjaan{
 		ID:                  trash.ID,
 		AlumniID:            trash.AlumniID,
+		PerusahaanID:        trash.PerusahaanID,
 		NamaPerusahaan:      trash.NamaPerusahaan,
 		PosisiJabatan:       trash.PosisiJabatan,
 		BidangIndustri:      trash.BidangIndustri,
+		KodeIndustri:        trash.KodeIndustri,
 		LokasiKerja:         trash.LokasiKerja,
+		Gaji:                trash.Gaji,
 		GajiRange:           trash.GajiRange,
 		TanggalMulaiKerja:   trash.TanggalMulaiKerja,
 		TanggalSelesaiKerja: trash.TanggalSelesaiKerja,
 		StatusPekerjaan:     trash.StatusPekerjaan,
 		DeskripsiPekerjaan:  trash.DeskripsiPekerjaan,
+		Lampiran:            trash.Lampiran,
 		CreatedAt:           trash.CreatedAt,
 		UpdatedAt:           time.Now(),
 	}
 
-	if _, err := r.collection.InsertOne(ctx, pekerjaan); err != nil {
+	if _, err := r.collection.InsertOne(ctx, pekerjaan, insertComment(ctx)); err != nil {
 		return err
 	}
 
-	_, err := r.trashCollection.DeleteOne(ctx, filter)
+	_, err := r.trashCollection.DeleteOne(ctx, filter, deleteComment(ctx))
 	return err
 }
 
 // ========================== GET TRASH ==========================
 func (r *pekerjaanRepository) GetTrash(ctx context.Context) ([]models.Trash, error) {
-	cursor, err := r.trashCollection.Find(ctx, bson.M{})
+	cursor, err := r.trashCollection.Find(ctx, bson.M{}, findComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -294,7 +432,7 @@ func (r *pekerjaanRepository) GetTrashByOwner(ctx context.Context, alumniID stri
 		return nil, fmt.Errorf("alumni_id tidak valid")
 	}
 
-	cursor, err := r.trashCollection.Find(ctx, bson.M{"alumni_id": alumniObj})
+	cursor, err := r.trashCollection.Find(ctx, bson.M{"alumni_id": alumniObj}, findComment(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -315,21 +453,12 @@ func (r *pekerjaanRepository) Delete(ctx context.Context, id string, alumniID *s
 		alumniObj, _ := primitive.ObjectIDFromHex(*alumniID)
 		filter["alumni_id"] = alumniObj
 	}
-	_, err := r.trashCollection.DeleteOne(ctx, filter)
+	_, err := r.trashCollection.DeleteOne(ctx, filter, deleteComment(ctx))
 	return err
 }
 
 // ========================== SEARCH, SORT, PAGINATION ==========================
-func (r *pekerjaanRepository) GetPekerjaanRepo(ctx context.Context, search, sortBy, order string, limit, offset int64) ([]models.Pekerjaan, error) {
-	filter := bson.M{
-		"$or": []bson.M{
-			{"nama_perusahaan": bson.M{"$regex": search, "$options": "i"}},
-			{"posisi_jabatan": bson.M{"$regex": search, "$options": "i"}},
-			{"bidang_industri": bson.M{"$regex": search, "$options": "i"}},
-			{"lokasi_kerja": bson.M{"$regex": search, "$options": "i"}},
-		},
-	}
-
+func (r *pekerjaanRepository) GetPekerjaanRepo(ctx context.Context, filter models.PekerjaanFilter, sortBy, order string, limit, offset int64) ([]models.Pekerjaan, error) {
 	sortOrder := 1
 	if order == "desc" {
 		sortOrder = -1
@@ -340,9 +469,9 @@ func (r *pekerjaanRepository) GetPekerjaanRepo(ctx context.Context, search, sort
 		SetLimit(limit).
 		SetSkip(offset)
 
-	cursor, err := r.collection.Find(ctx, filter, opts)
+	cursor, err := r.collection.Find(ctx, buildPekerjaanFilter(filter), opts, findComment(ctx))
 	if err != nil {
-		log.Println("Query error:", err)
+		slog.ErrorContext(ctx, "Query pekerjaan gagal", "err", err)
 		return nil, err
 	}
 	defer cursor.Close(ctx)
@@ -354,15 +483,79 @@ func (r *pekerjaanRepository) GetPekerjaanRepo(ctx context.Context, search, sort
 	return pekerjaan, nil
 }
 
-func (r *pekerjaanRepository) CountPekerjaanRepo(ctx context.Context, search string) (int64, error) {
-	filter := bson.M{
-		"$or": []bson.M{
-			{"nama_perusahaan": bson.M{"$regex": search, "$options": "i"}},
-			{"posisi_jabatan": bson.M{"$regex": search, "$options": "i"}},
-			{"bidang_industri": bson.M{"$regex": search, "$options": "i"}},
-			{"lokasi_kerja": bson.M{"$regex": search, "$options": "i"}},
-		},
-	}
-	count, err := r.collection.CountDocuments(ctx, filter)
+func (r *pekerjaanRepository) CountPekerjaanRepo(ctx context.Context, filter models.PekerjaanFilter) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, buildPekerjaanFilter(filter), countComment(ctx))
 	return count, err
 }
+
+// buildPekerjaanFilter menyusun filter MongoDB dari filter daftar pekerjaan.
+// Rentang gaji dicocokkan secara overlap setelah dikonversi ke periode filter.
+func buildPekerjaanFilter(f models.PekerjaanFilter) bson.M {
+	conditions := []bson.M{
+		{"$or": []bson.M{
+			{"nama_perusahaan": bson.M{"$regex": f.Search, "$options": "i"}},
+			{"posisi_jabatan": bson.M{"$regex": f.Search, "$options": "i"}},
+			{"bidang_industri": bson.M{"$regex": f.Search, "$options": "i"}},
+			{"lokasi_kerja": bson.M{"$regex": f.Search, "$options": "i"}},
+		}},
+	}
+
+	if f.Status != "" {
+		conditions = append(conditions, bson.M{"status_pekerjaan": f.Status})
+	}
+
+	if f.GajiMin > 0 || f.GajiMax > 0 {
+		periode := f.Periode
+		if periode == "" {
+			periode = models.GajiPeriodeBulanan
+		}
+		mataUang := f.MataUang
+		if mataUang == "" {
+			mataUang = "IDR"
+		}
+
+		var perPeriode []bson.M
+		for _, docPeriode := range []string{models.GajiPeriodeBulanan, models.GajiPeriodeTahunan} {
+			factor := 1.0
+			if docPeriode == models.GajiPeriodeTahunan && periode == models.GajiPeriodeBulanan {
+				factor = 12
+			} else if docPeriode == models.GajiPeriodeBulanan && periode == models.GajiPeriodeTahunan {
+				factor = 1.0 / 12
+			}
+
+			cond := bson.M{"gaji_periode": docPeriode}
+			var and []bson.M
+			if f.GajiMin > 0 {
+				// gaji_max 0 = tanpa batas atas
+				and = append(and, bson.M{"$or": []bson.M{
+					{"gaji_max": bson.M{"$gte": float64(f.GajiMin) * factor}},
+					{"gaji_max": 0},
+				}})
+			}
+			if f.GajiMax > 0 {
+				and = append(and, bson.M{"gaji_min": bson.M{"$lte": float64(f.GajiMax) * factor}})
+			}
+			cond["$and"] = and
+			perPeriode = append(perPeriode, cond)
+		}
+
+		conditions = append(conditions,
+			bson.M{"gaji_mata_uang": mataUang},
+			bson.M{"$or": perPeriode},
+		)
+	}
+
+	return bson.M{"$and": conditions}
+}
+
+// parseOptionalObjectID mengubah string hex menjadi ObjectID; string kosong menghasilkan nil
+func parseOptionalObjectID(hex string) (*primitive.ObjectID, error) {
+	if hex == "" {
+		return nil, nil
+	}
+	id, err := primitive.ObjectIDFromHex(hex)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PengajuanPekerjaanRepository interface {
+	Create(ctx context.Context, pengajuan *models.PengajuanPekerjaan) error
+	GetByID(ctx context.Context, id string) (*models.PengajuanPekerjaan, error)
+	GetAll(ctx context.Context, status string, alumniID *primitive.ObjectID) ([]models.PengajuanPekerjaan, error)
+	HasPending(ctx context.Context, pekerjaanID primitive.ObjectID) (bool, error)
+	Review(ctx context.Context, id primitive.ObjectID, status, komentar, reviewer string) error
+	Reopen(ctx context.Context, id primitive.ObjectID) error
+	SetPekerjaanID(ctx context.Context, id, pekerjaanID primitive.ObjectID) error
+}
+
+type pengajuanPekerjaanRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPengajuanPekerjaanRepository(database *mongo.Database) PengajuanPekerjaanRepository {
+	return &pengajuanPekerjaanRepository{collection: database.Collection("pengajuan_pekerjaan")}
+}
+
+// ========================== CREATE ==========================
+func (r *pengajuanPekerjaanRepository) Create(ctx context.Context, pengajuan *models.PengajuanPekerjaan) error {
+	pengajuan.ID = primitive.NewObjectID()
+	pengajuan.Status = models.StatusPengajuanPending
+	pengajuan.CreatedAt = time.Now()
+	pengajuan.UpdatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, pengajuan, insertComment(ctx))
+	return err
+}
+
+// ========================== GET BY ID ==========================
+func (r *pengajuanPekerjaanRepository) GetByID(ctx context.Context, id string) (*models.PengajuanPekerjaan, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("id pengajuan tidak valid")
+	}
+
+	var pengajuan models.PengajuanPekerjaan
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}, findOneComment(ctx)).Decode(&pengajuan)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pengajuan, nil
+}
+
+// GetAll mengambil pengajuan berdasarkan status (kosong = semua). Tanpa alumniID
+// (antrian admin) diurutkan dari yang terlama; milik alumni dari yang terbaru.
+func (r *pengajuanPekerjaanRepository) GetAll(ctx context.Context, status string, alumniID *primitive.ObjectID) ([]models.PengajuanPekerjaan, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	order := 1
+	if alumniID != nil {
+		filter["alumni_id"] = *alumniID
+		order = -1
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: order}})
+	cursor, err := r.collection.Find(ctx, filter, opts, findComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []models.PengajuanPekerjaan{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// HasPending mengecek apakah pekerjaan masih punya pengajuan perubahan yang belum direview
+func (r *pengajuanPekerjaanRepository) HasPending(ctx context.Context, pekerjaanID primitive.ObjectID) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{
+		"pekerjaan_id": pekerjaanID,
+		"jenis":        models.JenisPengajuanUbah,
+		"status":       models.StatusPengajuanPending,
+	}, countComment(ctx))
+	return count > 0, err
+}
+
+// Review mengubah status pengajuan yang masih pending. mongo.ErrNoDocuments jika
+// pengajuan sudah direview (mis. oleh admin lain).
+func (r *pengajuanPekerjaanRepository) Review(ctx context.Context, id primitive.ObjectID, status, komentar, reviewer string) error {
+	now := time.Now()
+	update := bson.M{"$set": bson.M{
+		"status":        status,
+		"komentar":      komentar,
+		"ditinjau_oleh": reviewer,
+		"ditinjau_pada": now,
+		"updated_at":    now,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "status": models.StatusPengajuanPending}, update, updateComment(ctx))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Reopen mengembalikan pengajuan ke pending, dipakai jika penyimpanan pekerjaan gagal setelah disetujui
+func (r *pengajuanPekerjaanRepository) Reopen(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{
+		"$set":   bson.M{"status": models.StatusPengajuanPending, "updated_at": time.Now()},
+		"$unset": bson.M{"komentar": "", "ditinjau_oleh": "", "ditinjau_pada": ""},
+	}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update, updateComment(ctx))
+	return err
+}
+
+// SetPekerjaanID mencatat pekerjaan yang dibuat dari pengajuan baru yang disetujui
+func (r *pengajuanPekerjaanRepository) SetPekerjaanID(ctx context.Context, id, pekerjaanID primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"pekerjaan_id": pekerjaanID}}, updateComment(ctx))
+	return err
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PerusahaanRepository interface {
+	GetAll(ctx context.Context, search string) ([]models.Perusahaan, error)
+	GetByID(ctx context.Context, id string) (*models.Perusahaan, error)
+	FindByNormalizedName(ctx context.Context, normal string) (*models.Perusahaan, error)
+	Create(ctx context.Context, perusahaan *models.Perusahaan) error
+	Update(ctx context.Context, perusahaan *models.Perusahaan) error
+	Delete(ctx context.Context, ids []primitive.ObjectID) error
+	GetAlumni(ctx context.Context, id primitive.ObjectID) ([]models.AlumniPerusahaan, error)
+	GetUnlinkedNames(ctx context.Context) ([]models.NamaPerusahaanTanpaLink, error)
+	LinkPekerjaan(ctx context.Context, namaPerusahaan []string, id primitive.ObjectID) (int64, error)
+	ReassignPekerjaan(ctx context.Context, from []primitive.ObjectID, to primitive.ObjectID) (int64, error)
+}
+
+type perusahaanRepository struct {
+	collection          *mongo.Collection
+	pekerjaanCollection *mongo.Collection
+	trashCollection     *mongo.Collection
+}
+
+func NewPerusahaanRepository(database *mongo.Database) PerusahaanRepository {
+	return &perusahaanRepository{
+		collection:          database.Collection("companies"),
+		pekerjaanCollection: database.Collection("pekerjaan_alumni"),
+		trashCollection:     database.Collection("trash_pekerjaan"),
+	}
+}
+
+// ========================== GET ALL ==========================
+func (r *perusahaanRepository) GetAll(ctx context.Context, search string) ([]models.Perusahaan, error) {
+	filter := bson.M{}
+	if search != "" {
+		pattern := bson.M{"$regex": regexp.QuoteMeta(search), "$options": "i"}
+		filter["$or"] = []bson.M{{"nama": pattern}, {"alias": pattern}}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "nama", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts, findComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []models.Perusahaan{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ========================== GET BY ID ==========================
+func (r *perusahaanRepository) GetByID(ctx context.Context, id string) (*models.Perusahaan, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("perusahaan_id tidak valid")
+	}
+
+	var perusahaan models.Perusahaan
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}, findOneComment(ctx)).Decode(&perusahaan)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &perusahaan, nil
+}
+
+// FindByNormalizedName mencari perusahaan yang nama kanonik atau aliasnya cocok
+func (r *perusahaanRepository) FindByNormalizedName(ctx context.Context, normal string) (*models.Perusahaan, error) {
+	if normal == "" {
+		return nil, nil
+	}
+
+	filter := bson.M{"$or": []bson.M{{"nama_normal": normal}, {"alias_normal": normal}}}
+	var perusahaan models.Perusahaan
+	err := r.collection.FindOne(ctx, filter, findOneComment(ctx)).Decode(&perusahaan)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &perusahaan, nil
+}
+
+// ========================== CREATE ==========================
+func (r *perusahaanRepository) Create(ctx context.Context, perusahaan *models.Perusahaan) error {
+	perusahaan.ID = primitive.NewObjectID()
+	perusahaan.CreatedAt = time.Now()
+	perusahaan.UpdatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, perusahaan, insertComment(ctx))
+	return err
+}
+
+// ========================== UPDATE ==========================
+func (r *perusahaanRepository) Update(ctx context.Context, perusahaan *models.Perusahaan) error {
+	perusahaan.UpdatedAt = time.Now()
+
+	update := bson.M{"$set": bson.M{
+		"nama":            perusahaan.Nama,
+		"nama_normal":     perusahaan.NamaNormal,
+		"alias":           perusahaan.Alias,
+		"alias_normal":    perusahaan.AliasNormal,
+		"bidang_industri": perusahaan.BidangIndustri,
+		"lokasi":          perusahaan.Lokasi,
+		"updated_at":      perusahaan.UpdatedAt,
+	}}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": perusahaan.ID}, update, updateComment(ctx))
+	return err
+}
+
+// ========================== DELETE ==========================
+func (r *perusahaanRepository) Delete(ctx context.Context, ids []primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, deleteComment(ctx))
+	return err
+}
+
+// ========================== ALUMNI PER PERUSAHAAN ==========================
+func (r *perusahaanRepository) GetAlumni(ctx context.Context, id primitive.ObjectID) ([]models.AlumniPerusahaan, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"perusahaan_id": id}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "alumni",
+			"localField":   "alumni_id",
+			"foreignField": "_id",
+			"as":           "alumni",
+		}}},
+		{{Key: "$unwind", Value: "$alumni"}},
+		{{Key: "$match", Value: bson.M{"alumni.is_deleted": false}}},
+		{{Key: "$project", Value: bson.M{
+			"alumni_id":             1,
+			"nama":                  "$alumni.nama",
+			"jurusan":               "$alumni.jurusan",
+			"angkatan":              "$alumni.angkatan",
+			"posisi_jabatan":        1,
+			"tanggal_mulai_kerja":   1,
+			"tanggal_selesai_kerja": 1,
+			"status_pekerjaan":      1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "tanggal_mulai_kerja", Value: -1}}}},
+	}
+
+	cursor, err := r.pekerjaanCollection.Aggregate(ctx, pipeline, aggregateComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []models.AlumniPerusahaan{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetUnlinkedNames mengambil nama_perusahaan unik dari pekerjaan yang belum punya perusahaan_id
+func (r *perusahaanRepository) GetUnlinkedNames(ctx context.Context) ([]models.NamaPerusahaanTanpaLink, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"perusahaan_id": nil}}},
+		{{Key: "$group", Value: bson.M{"_id": "$nama_perusahaan", "jumlah": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "jumlah", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := r.pekerjaanCollection.Aggregate(ctx, pipeline, aggregateComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []models.NamaPerusahaanTanpaLink{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// LinkPekerjaan menghubungkan pekerjaan (termasuk di trash) dengan nama_perusahaan tertentu ke perusahaan
+func (r *perusahaanRepository) LinkPekerjaan(ctx context.Context, namaPerusahaan []string, id primitive.ObjectID) (int64, error) {
+	filter := bson.M{"nama_perusahaan": bson.M{"$in": namaPerusahaan}, "perusahaan_id": nil}
+	return r.updatePekerjaan(ctx, filter, id)
+}
+
+// ReassignPekerjaan memindahkan pekerjaan dari perusahaan lama ke perusahaan tujuan (merge)
+func (r *perusahaanRepository) ReassignPekerjaan(ctx context.Context, from []primitive.ObjectID, to primitive.ObjectID) (int64, error) {
+	return r.updatePekerjaan(ctx, bson.M{"perusahaan_id": bson.M{"$in": from}}, to)
+}
+
+func (r *perusahaanRepository) updatePekerjaan(ctx context.Context, filter bson.M, id primitive.ObjectID) (int64, error) {
+	update := bson.M{"$set": bson.M{"perusahaan_id": id}}
+
+	result, err := r.pekerjaanCollection.UpdateMany(ctx, filter, update, updateComment(ctx))
+	if err != nil {
+		return 0, err
+	}
+	if _, err := r.trashCollection.UpdateMany(ctx, filter, update, updateComment(ctx)); err != nil {
+		return result.ModifiedCount, err
+	}
+	return result.ModifiedCount, nil
+}
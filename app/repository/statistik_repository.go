@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	models "crud-app/app/model"
+	"crud-app/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Batas rentang gaji bulanan (IDR) untuk statistik gaji
+var SalaryBandBoundaries = []int64{0, 3_000_000, 5_000_000, 7_000_000, 10_000_000, 15_000_000, 20_000_000}
+
+type StatistikRepository interface {
+	Keterserapan(ctx context.Context, af models.AlumniFilter, groupBy string) ([]models.StatistikKeterserapan, error)
+	MasaTunggu(ctx context.Context, af models.AlumniFilter, groupBy string) ([]models.StatistikMasaTunggu, error)
+	Distribusi(ctx context.Context, af models.AlumniFilter, pf models.PekerjaanFilter, field string) ([]models.StatistikDistribusi, error)
+	DistribusiIndustri(ctx context.Context, af models.AlumniFilter, pf models.PekerjaanFilter, level string) ([]models.StatistikDistribusi, error)
+	DistribusiGaji(ctx context.Context, af models.AlumniFilter, pf models.PekerjaanFilter) (map[int64]int, error)
+}
+
+type statistikRepository struct {
+	alumniCollection    *mongo.Collection
+	pekerjaanCollection *mongo.Collection
+}
+
+func NewStatistikRepository(database *mongo.Database) StatistikRepository {
+	return &statistikRepository{
+		alumniCollection:    database.Collection("alumni"),
+		pekerjaanCollection: database.Collection("pekerjaan_alumni"),
+	}
+}
+
+// groupKey mengembalikan ekspresi _id untuk $group; kosong = satu kelompok total
+func groupKey(groupBy string) interface{} {
+	if groupBy == "" {
+		return nil
+	}
+	return "$" + groupBy
+}
+
+// lookupPekerjaanStage menggabungkan dokumen alumni dengan pekerjaannya
+var lookupPekerjaanStage = bson.D{{Key: "$lookup", Value: bson.M{
+	"from":         "pekerjaan_alumni",
+	"localField":   "_id",
+	"foreignField": "alumni_id",
+	"as":           "pekerjaan",
+}}}
+
+// pekerjaanWithAlumniPipeline memfilter pekerjaan lalu menyaring berdasarkan data alumninya
+func pekerjaanWithAlumniPipeline(af models.AlumniFilter, pf models.PekerjaanFilter) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: buildPekerjaanFilter(pf)}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "alumni",
+			"localField":   "alumni_id",
+			"foreignField": "_id",
+			"as":           "alumni",
+		}}},
+		{{Key: "$unwind", Value: "$alumni"}},
+		{{Key: "$match", Value: buildAlumniFilter(af, "alumni.")}},
+	}
+}
+
+// ================= KETERSERAPAN KERJA =================
+func (r *statistikRepository) Keterserapan(ctx context.Context, af models.AlumniFilter, groupBy string) ([]models.StatistikKeterserapan, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: buildAlumniFilter(af, "")}},
+		lookupPekerjaanStage,
+		{{Key: "$group", Value: bson.M{
+			"_id":          groupKey(groupBy),
+			"total_alumni": bson.M{"$sum": 1},
+			"bekerja": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{bson.M{"$size": "$pekerjaan"}, 0}}, 1, 0,
+			}}},
+			// Alumni yang sedang cuti tetap terhitung bekerja
+			"bekerja_saat_ini": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{bson.M{"$size": bson.M{"$setIntersection": bson.A{
+					"$pekerjaan.status_pekerjaan",
+					bson.A{models.StatusPekerjaanAktif, models.StatusPekerjaanCuti},
+				}}}, 0}}, 1, 0,
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	var results []models.StatistikKeterserapan
+	if err := r.aggregate(ctx, r.alumniCollection, pipeline, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ================= MASA TUNGGU =================
+func (r *statistikRepository) MasaTunggu(ctx context.Context, af models.AlumniFilter, groupBy string) ([]models.StatistikMasaTunggu, error) {
+	match := buildAlumniFilter(af, "")
+	if _, ok := match["tahun_lulus"]; !ok {
+		match["tahun_lulus"] = bson.M{"$gt": 0}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		lookupPekerjaanStage,
+		{{Key: "$match", Value: bson.M{"pekerjaan.0": bson.M{"$exists": true}}}},
+		{{Key: "$project", Value: bson.M{
+			"kelompok": groupKey(groupBy),
+			"pertama":  bson.M{"$min": "$pekerjaan.tanggal_mulai_kerja"},
+			"lulus": bson.M{"$dateFromParts": bson.M{
+				"year":  "$tahun_lulus",
+				"month": int(utils.GraduationMonth),
+				"day":   1,
+			}},
+		}}},
+		// Selisih bulan kalender (sama dengan $dateDiff unit month, tanpa butuh MongoDB 5.0).
+		// Bekerja sebelum lulus dihitung sebagai masa tunggu 0 bulan.
+		{{Key: "$project", Value: bson.M{
+			"kelompok": 1,
+			"tunggu": bson.M{"$max": bson.A{0, bson.M{"$add": bson.A{
+				bson.M{"$multiply": bson.A{
+					bson.M{"$subtract": bson.A{bson.M{"$year": "$pertama"}, bson.M{"$year": "$lulus"}}}, 12,
+				}},
+				bson.M{"$subtract": bson.A{bson.M{"$month": "$pertama"}, bson.M{"$month": "$lulus"}}},
+			}}}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":             "$kelompok",
+			"jumlah_alumni":   bson.M{"$sum": 1},
+			"rata_rata_bulan": bson.M{"$avg": "$tunggu"},
+			"min_bulan":       bson.M{"$min": "$tunggu"},
+			"max_bulan":       bson.M{"$max": "$tunggu"},
+			"maks_enam_bulan": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$lte": bson.A{"$tunggu", 6}}, 1, 0,
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	var results []models.StatistikMasaTunggu
+	if err := r.aggregate(ctx, r.alumniCollection, pipeline, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ================= DISTRIBUSI (INDUSTRI / LOKASI) =================
+func (r *statistikRepository) Distribusi(ctx context.Context, af models.AlumniFilter, pf models.PekerjaanFilter, field string) ([]models.StatistikDistribusi, error) {
+	pipeline := append(pekerjaanWithAlumniPipeline(af, pf),
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":              bson.M{"$trim": bson.M{"input": "$" + field}},
+			"jumlah_pekerjaan": bson.M{"$sum": 1},
+			"alumni":           bson.M{"$addToSet": "$alumni_id"},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"jumlah_pekerjaan": 1,
+			"jumlah_alumni":    bson.M{"$size": "$alumni"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "jumlah_pekerjaan", Value: -1}, {Key: "_id", Value: 1}}}},
+	)
+
+	var results []models.StatistikDistribusi
+	if err := r.aggregate(ctx, r.pekerjaanCollection, pipeline, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// LabelIndustriTanpaKode dipakai untuk pekerjaan yang belum punya kode_industri
+const LabelIndustriTanpaKode = "Belum terpetakan"
+
+// DistribusiIndustri mengelompokkan pekerjaan per kode KBLI pada level tertentu
+// (kategori atau golongan_pokok) berdasarkan path kode_industri.
+func (r *statistikRepository) DistribusiIndustri(ctx context.Context, af models.AlumniFilter, pf models.PekerjaanFilter, level string) ([]models.StatistikDistribusi, error) {
+	depth := 0
+	if level == models.LevelIndustriGolonganPokok {
+		depth = 1
+	}
+
+	pipeline := append(pekerjaanWithAlumniPipeline(af, pf),
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "industri",
+			"localField":   "kode_industri",
+			"foreignField": "_id",
+			"as":           "industri",
+		}}},
+		bson.D{{Key: "$set", Value: bson.M{"industri": bson.M{"$arrayElemAt": bson.A{"$industri", 0}}}}},
+		// Leluhur pada level yang diminta; kode yang lebih umum dari level itu dipakai apa adanya
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$ifNull": bson.A{
+				bson.M{"$arrayElemAt": bson.A{"$industri.path", depth}},
+				"$industri._id",
+			}},
+			"jumlah_pekerjaan": bson.M{"$sum": 1},
+			"alumni":           bson.M{"$addToSet": "$alumni_id"},
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "industri",
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "industri",
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id": bson.M{"$ifNull": bson.A{
+				bson.M{"$arrayElemAt": bson.A{"$industri.judul", 0}},
+				LabelIndustriTanpaKode,
+			}},
+			"kode":             "$_id",
+			"jumlah_pekerjaan": 1,
+			"jumlah_alumni":    bson.M{"$size": "$alumni"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "jumlah_pekerjaan", Value: -1}, {Key: "kode", Value: 1}}}},
+	)
+
+	var results []models.StatistikDistribusi
+	if err := r.aggregate(ctx, r.pekerjaanCollection, pipeline, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ================= DISTRIBUSI GAJI =================
+// DistribusiGaji mengembalikan jumlah pekerjaan per batas bawah band (SalaryBandBoundaries).
+// Gaji dihitung dari titik tengah rentang, dikonversi ke bulanan, hanya untuk IDR.
+func (r *statistikRepository) DistribusiGaji(ctx context.Context, af models.AlumniFilter, pf models.PekerjaanFilter) (map[int64]int, error) {
+	pf.MataUang = "IDR"
+	boundaries := bson.A{}
+	for _, b := range SalaryBandBoundaries {
+		boundaries = append(boundaries, b)
+	}
+	last := SalaryBandBoundaries[len(SalaryBandBoundaries)-1]
+
+	pipeline := append(pekerjaanWithAlumniPipeline(af, pf),
+		bson.D{{Key: "$match", Value: bson.M{
+			"gaji_mata_uang": "IDR",
+			"$or":            bson.A{bson.M{"gaji_min": bson.M{"$gt": 0}}, bson.M{"gaji_max": bson.M{"$gt": 0}}},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"bulanan": bson.M{"$divide": bson.A{
+				bson.M{"$cond": bson.A{
+					bson.M{"$gt": bson.A{"$gaji_max", 0}},
+					bson.M{"$avg": bson.A{"$gaji_min", "$gaji_max"}},
+					"$gaji_min",
+				}},
+				bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$gaji_periode", models.GajiPeriodeTahunan}}, 12, 1}},
+			}},
+		}}},
+		bson.D{{Key: "$bucket", Value: bson.M{
+			"groupBy":    "$bulanan",
+			"boundaries": boundaries,
+			"default":    last,
+			"output":     bson.M{"jumlah": bson.M{"$sum": 1}},
+		}}},
+	)
+
+	var rows []struct {
+		ID     int64 `bson:"_id"`
+		Jumlah int   `bson:"jumlah"`
+	}
+	if err := r.aggregate(ctx, r.pekerjaanCollection, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		result[row.ID] += row.Jumlah
+	}
+	return result, nil
+}
+
+func (r *statistikRepository) aggregate(ctx context.Context, collection *mongo.Collection, pipeline mongo.Pipeline, out interface{}) error {
+	cursor, err := collection.Aggregate(ctx, pipeline, aggregateComment(ctx))
+	if err != nil {
+		return fmt.Errorf("aggregate %s: %w", collection.Name(), err)
+	}
+	defer cursor.Close(ctx)
+	return cursor.All(ctx, out)
+}
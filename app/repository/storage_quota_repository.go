@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type StorageQuotaRepository interface {
+	GetByUserID(ctx context.Context, userID primitive.ObjectID) (*models.StorageQuota, error)
+	Set(ctx context.Context, quota *models.StorageQuota) error
+	Delete(ctx context.Context, userID primitive.ObjectID) (bool, error)
+}
+
+type storageQuotaRepository struct {
+	collection *mongo.Collection
+}
+
+func NewStorageQuotaRepository(database *mongo.Database) StorageQuotaRepository {
+	return &storageQuotaRepository{collection: database.Collection("storage_quotas")}
+}
+
+// GetByUserID mengambil kuota khusus user, nil jika user memakai kuota bawaan role
+func (r *storageQuotaRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID) (*models.StorageQuota, error) {
+	var quota models.StorageQuota
+	err := r.collection.FindOne(ctx, bson.M{"_id": userID}, findOneComment(ctx)).Decode(&quota)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// Set membuat atau mengganti kuota khusus user
+func (r *storageQuotaRepository) Set(ctx context.Context, quota *models.StorageQuota) error {
+	quota.UpdatedAt = time.Now()
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": quota.UserID}, quota, options.Replace().SetUpsert(true), replaceComment(ctx))
+	return err
+}
+
+// Delete menghapus kuota khusus sehingga user kembali memakai kuota role. false jika tidak ada.
+func (r *storageQuotaRepository) Delete(ctx context.Context, userID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": userID}, deleteComment(ctx))
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
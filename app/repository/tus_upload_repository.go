@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TusUploadRepository interface {
+	Create(ctx context.Context, upload *models.TusUpload) error
+	GetByID(ctx context.Context, id string) (*models.TusUpload, error)
+	AdvanceOffset(ctx context.Context, id primitive.ObjectID, from, to int64) (bool, error)
+	SetFileID(ctx context.Context, id, fileID primitive.ObjectID) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	GetExpired(ctx context.Context, now time.Time) ([]models.TusUpload, error)
+}
+
+type tusUploadRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTusUploadRepository(database *mongo.Database) TusUploadRepository {
+	return &tusUploadRepository{collection: database.Collection("tus_uploads")}
+}
+
+func (r *tusUploadRepository) Create(ctx context.Context, upload *models.TusUpload) error {
+	upload.ID = primitive.NewObjectID()
+	upload.CreatedAt = time.Now()
+	upload.UpdatedAt = upload.CreatedAt
+
+	_, err := r.collection.InsertOne(ctx, upload, insertComment(ctx))
+	return err
+}
+
+// GetByID mengembalikan nil tanpa error jika upload tidak ditemukan atau id tidak valid
+func (r *tusUploadRepository) GetByID(ctx context.Context, id string) (*models.TusUpload, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	var upload models.TusUpload
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}, findOneComment(ctx)).Decode(&upload)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// AdvanceOffset memajukan offset hanya jika offset saat ini masih from, sehingga dua PATCH
+// yang berjalan bersamaan tidak bisa sama-sama tercatat. false jika offset sudah berubah.
+func (r *tusUploadRepository) AdvanceOffset(ctx context.Context, id primitive.ObjectID, from, to int64) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "offset": from},
+		bson.M{"$set": bson.M{"offset": to, "updated_at": time.Now()}},
+		updateComment(ctx),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *tusUploadRepository) SetFileID(ctx context.Context, id, fileID primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"file_id": fileID, "updated_at": time.Now()}},
+		updateComment(ctx),
+	)
+	return err
+}
+
+func (r *tusUploadRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id}, deleteComment(ctx))
+	return err
+}
+
+// GetExpired mengambil upload yang sudah melewati masa berlakunya
+func (r *tusUploadRepository) GetExpired(ctx context.Context, now time.Time) ([]models.TusUpload, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"expires_at": bson.M{"$lt": now}}, findComment(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []models.TusUpload{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -3,23 +3,27 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"strconv"
 	"strings"
-	"time"
 
 	models "crud-app/app/model"
 	"crud-app/app/repository"
+	"crud-app/config"
+	"crud-app/metrics"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type AlumniService struct {
-	repo repository.AlumniRepository
+	repo     repository.AlumniRepository
+	fileRepo repository.FileRepository
+	timeouts config.Timeouts
 }
 
-func NewAlumniService(r repository.AlumniRepository) *AlumniService {
-	return &AlumniService{repo: r}
+func NewAlumniService(r repository.AlumniRepository, fileRepo repository.FileRepository, timeouts config.Timeouts) *AlumniService {
+	return &AlumniService{repo: r, fileRepo: fileRepo, timeouts: timeouts}
 }
 
 // GetAll godoc
@@ -33,11 +37,10 @@ func NewAlumniService(r repository.AlumniRepository) *AlumniService {
 // @Security Bearer
 // @Router /unair/alumni/all [get]
 func (s *AlumniService) GetAll(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, s.timeouts.Read)
 	defer cancel()
 
-	username, _ := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/alumni", username)
+	slog.DebugContext(ctx, "Akses daftar alumni")
 
 	list, err := s.repo.GetAll(ctx)
 	if err != nil {
@@ -51,23 +54,23 @@ func (s *AlumniService) GetAll(c *fiber.Ctx) error {
 
 // GetByID godoc
 // @Summary Mendapatkan data alumni berdasarkan ID
-// @Description Mengambil data alumni tertentu berdasarkan ID
+// @Description Mengambil data alumni tertentu berdasarkan ID. Dengan embed=file, metadata foto profil ikut disertakan
 // @Tags Alumni
 // @Accept json
 // @Produce json
 // @Param id path string true "ID Alumni (MongoDB ObjectID)"
+// @Param embed query string false "Isi file untuk menyertakan metadata foto profil"
 // @Success 200 {object} map[string]interface{} "success response dengan data alumni"
 // @Failure 404 {object} map[string]interface{} "alumni tidak ditemukan"
 // @Failure 500 {object} map[string]interface{} "error response"
 // @Security Bearer
 // @Router /unair/alumni/{id} [get]
 func (s *AlumniService) GetByID(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, s.timeouts.Read)
 	defer cancel()
 
-	username, _ := c.Locals("username").(string)
 	id := c.Params("id")
-	log.Printf("User %s mengakses GET /api/alumni/%s", username, id)
+	slog.DebugContext(ctx, "Akses detail alumni", "alumni_id", id)
 
 	alumni, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -76,6 +79,11 @@ func (s *AlumniService) GetByID(c *fiber.Ctx) error {
 	if alumni == nil {
 		return c.Status(404).JSON(fiber.Map{"error": "Alumni tidak ditemukan"})
 	}
+	if c.Query("embed") == "file" {
+		if err := s.embedFoto(ctx, alumni); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data foto alumni"})
+		}
+	}
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    alumni,
@@ -95,11 +103,10 @@ func (s *AlumniService) GetByID(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/alumni [post]
 func (s *AlumniService) Create(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, s.timeouts.Write)
 	defer cancel()
 
-	username, _ := c.Locals("username").(string)
-	log.Printf("Admin %s menambah alumni baru", username)
+	slog.InfoContext(ctx, "Admin menambah alumni baru")
 
 	var req models.CreateAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -137,12 +144,11 @@ func (s *AlumniService) Create(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/alumni/{id} [put]
 func (s *AlumniService) Update(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, s.timeouts.Write)
 	defer cancel()
 
-	username, _ := c.Locals("username").(string)
 	id := c.Params("id")
-	log.Printf("Admin %s mengupdate alumni ID %s", username, id)
+	slog.InfoContext(ctx, "Admin mengupdate alumni", "alumni_id", id)
 
 	var req models.UpdateAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -181,13 +187,12 @@ func (s *AlumniService) Update(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/alumni/{id} [delete]
 func (s *AlumniService) SoftDelete(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, s.timeouts.Write)
 	defer cancel()
 
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
-	log.Printf("%s mencoba menghapus alumni ID %s", username, id)
+	slog.InfoContext(ctx, "Mencoba menghapus alumni", "alumni_id", id)
 
 	alumni, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -201,6 +206,7 @@ func (s *AlumniService) SoftDelete(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": fmt.Sprintf("Gagal menghapus alumni: %v", err)})
 	}
+	metrics.MovedToTrash("alumni")
 
 	return c.JSON(fiber.Map{
 		"success": true,
@@ -220,13 +226,12 @@ func (s *AlumniService) SoftDelete(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/alumni/{id} [patch]
 func (s *AlumniService) Restore(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, s.timeouts.Write)
 	defer cancel()
 
-	username := c.Locals("username").(string)
 	id := c.Params("id")
 
-	log.Printf("User %s merestore alumni ID %s", username, id)
+	slog.InfoContext(ctx, "Merestore alumni", "alumni_id", id)
 
 	err := s.repo.Restore(ctx, id)
 	if err != nil {
@@ -250,11 +255,10 @@ func (s *AlumniService) Restore(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/alumni/without-pekerjaan [get]
 func (s *AlumniService) GetWithoutPekerjaan(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, s.timeouts.Read)
 	defer cancel()
 
-	username, _ := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/alumni/without-pekerjaan", username)
+	slog.DebugContext(ctx, "Akses daftar alumni tanpa pekerjaan")
 
 	count, err := s.repo.CountWithoutPekerjaan(ctx)
 	if err != nil {
@@ -273,6 +277,129 @@ func (s *AlumniService) GetWithoutPekerjaan(c *fiber.Ctx) error {
 	})
 }
 
+// SetFoto godoc
+// @Summary Mengatur foto profil alumni
+// @Description Menghubungkan file foto yang sudah di-upload (/files/upload/foto) sebagai foto profil alumni. Admin untuk semua alumni, alumni hanya untuk dirinya sendiri dengan file miliknya
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param id path string true "ID Alumni (MongoDB ObjectID)"
+// @Param body body models.SetFotoAlumniRequest true "ID file foto"
+// @Success 200 {object} map[string]interface{} "success response dengan data alumni dan foto"
+// @Failure 400 {object} map[string]interface{} "file_id kosong atau file bukan gambar"
+// @Failure 403 {object} map[string]interface{} "bukan data milik sendiri"
+// @Failure 404 {object} map[string]interface{} "alumni atau file tidak ditemukan"
+// @Failure 409 {object} map[string]interface{} "file masih dikarantina"
+// @Failure 500 {object} map[string]interface{} "error response"
+// @Security Bearer
+// @Router /unair/alumni/{id}/foto [put]
+func (s *AlumniService) SetFoto(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	id := c.Params("id")
+	if !canManageAlumni(c, id) {
+		return c.Status(403).JSON(fiber.Map{"error": "Kamu tidak bisa mengubah foto alumni lain"})
+	}
+
+	var req models.SetFotoAlumniRequest
+	if err := c.BodyParser(&req); err != nil || req.FileID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "file_id wajib diisi"})
+	}
+
+	alumni, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data alumni"})
+	}
+	if alumni == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Alumni tidak ditemukan"})
+	}
+
+	file, err := s.fileRepo.FindByID(ctx, req.FileID)
+	if err != nil || !canAccessFile(c, file) {
+		return c.Status(404).JSON(fiber.Map{"error": "File tidak ditemukan"})
+	}
+	if !strings.HasPrefix(file.FileType, "image/") {
+		return c.Status(400).JSON(fiber.Map{"error": "File foto profil harus berupa gambar"})
+	}
+	if file.Quarantined() {
+		return c.Status(409).JSON(fiber.Map{"error": "File sedang dikarantina (belum lolos pemindaian malware atau isinya hilang)"})
+	}
+
+	if err := s.repo.SetFotoFile(ctx, id, &file.ID); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengatur foto alumni"})
+	}
+
+	alumni.FotoFileID = &file.ID
+	alumni.Foto = newFileResponse(file)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    alumni,
+		"message": "Foto alumni berhasil diatur",
+	})
+}
+
+// DeleteFoto godoc
+// @Summary Melepas foto profil alumni
+// @Description Menghapus hubungan foto profil dari data alumni. File fotonya tidak dihapus (gunakan DELETE /files/{id})
+// @Tags Alumni
+// @Produce json
+// @Param id path string true "ID Alumni (MongoDB ObjectID)"
+// @Success 200 {object} map[string]interface{} "success response dengan message"
+// @Failure 403 {object} map[string]interface{} "bukan data milik sendiri"
+// @Failure 404 {object} map[string]interface{} "alumni tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "error response"
+// @Security Bearer
+// @Router /unair/alumni/{id}/foto [delete]
+func (s *AlumniService) DeleteFoto(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	id := c.Params("id")
+	if !canManageAlumni(c, id) {
+		return c.Status(403).JSON(fiber.Map{"error": "Kamu tidak bisa mengubah foto alumni lain"})
+	}
+
+	alumni, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data alumni"})
+	}
+	if alumni == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Alumni tidak ditemukan"})
+	}
+
+	if err := s.repo.SetFotoFile(ctx, id, nil); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal melepas foto alumni"})
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Foto alumni berhasil dilepas",
+	})
+}
+
+// embedFoto mengisi metadata foto profil alumni (jika ada)
+func (s *AlumniService) embedFoto(ctx context.Context, alumni *models.Alumni) error {
+	if alumni.FotoFileID == nil {
+		return nil
+	}
+	files, err := loadFileResponses(ctx, s.fileRepo, []primitive.ObjectID{*alumni.FotoFileID})
+	if err != nil {
+		return err
+	}
+	alumni.Foto = files[*alumni.FotoFileID]
+	return nil
+}
+
+// canManageAlumni: admin boleh mengubah semua alumni, alumni hanya datanya sendiri
+func canManageAlumni(c *fiber.Ctx, alumniID string) bool {
+	role, _ := c.Locals("role").(string)
+	if role == "admin" {
+		return true
+	}
+	ownID, _ := c.Locals("alumni_id").(string)
+	return ownID != "" && ownID == alumniID
+}
+
 // GetAlumniService godoc
 // @Summary Mendapatkan daftar alumni dengan pencarian, sorting, dan pagination
 // @Description Mengambil daftar alumni berdasarkan parameter pencarian, pengurutan, dan batas halaman dengan informasi meta
@@ -284,12 +411,15 @@ func (s *AlumniService) GetWithoutPekerjaan(c *fiber.Ctx) error {
 // @Param sortBy query string false "Kolom untuk sorting: nim, nama, jurusan, angkatan, tahun_lulus, email, created_at (default: nama)"
 // @Param order query string false "Urutan sorting: asc atau desc (default: asc)"
 // @Param search query string false "Kata kunci pencarian di semua field"
+// @Param jurusan query string false "Filter jurusan (tidak case-sensitive)"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
 // @Success 200 {object} models.AlumniResponse "success response dengan data alumni dan meta informasi"
 // @Failure 500 {object} map[string]interface{} "error response"
 // @Security Bearer
 // @Router /unair/alumni [get]
 func (s *AlumniService) GetAlumniService(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, s.timeouts.Read)
 	defer cancel()
 
 	page, _ := strconv.Atoi(c.Query("page", "1"))
@@ -311,12 +441,14 @@ func (s *AlumniService) GetAlumniService(c *fiber.Ctx) error {
 		order = "asc"
 	}
 
-	alumni, err := s.repo.GetAlumniRepo(ctx, search, sortBy, order, int64(limit), offset)
+	filter := parseAlumniFilter(c)
+
+	alumni, err := s.repo.GetAlumniRepo(ctx, filter, sortBy, order, int64(limit), offset)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data alumni"})
 	}
 
-	total, err := s.repo.CountAlumniRepo(ctx, search)
+	total, err := s.repo.CountAlumniRepo(ctx, filter)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung total alumni"})
 	}
@@ -335,4 +467,14 @@ func (s *AlumniService) GetAlumniService(c *fiber.Ctx) error {
 	}
 
 	return c.JSON(response)
-}
\ No newline at end of file
+}
+
+// parseAlumniFilter membaca filter daftar alumni dari query string
+func parseAlumniFilter(c *fiber.Ctx) models.AlumniFilter {
+	return models.AlumniFilter{
+		Search:     c.Query("search", ""),
+		Jurusan:    c.Query("jurusan", ""),
+		Angkatan:   c.QueryInt("angkatan", 0),
+		TahunLulus: c.QueryInt("tahun_lulus", 0),
+	}
+}
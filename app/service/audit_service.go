@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/config"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Batas jumlah audit log per request
+const (
+	defaultLimitAudit = 50
+	maxLimitAudit     = 500
+)
+
+type AuditService struct {
+	repo     repository.AuditRepository
+	timeouts config.Timeouts
+}
+
+func NewAuditService(r repository.AuditRepository, timeouts config.Timeouts) *AuditService {
+	return &AuditService{repo: r, timeouts: timeouts}
+}
+
+// GetAll godoc
+// @Summary Daftar audit log
+// @Description Mengambil catatan aksi admin terbaru (admin only)
+// @Tags Audit
+// @Produce json
+// @Param aksi query string false "Filter aksi, mis. hapus_file"
+// @Param entitas query string false "Filter entitas (nama collection), mis. files"
+// @Param entitas_id query string false "Filter ID entitas"
+// @Param limit query int false "Jumlah data (default 50, maks 500)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/audit-log [get]
+func (s *AuditService) GetAll(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	limit := c.QueryInt("limit", defaultLimitAudit)
+	if limit < 1 {
+		limit = defaultLimitAudit
+	}
+	if limit > maxLimitAudit {
+		limit = maxLimitAudit
+	}
+
+	list, err := s.repo.GetAll(ctx, models.AuditFilter{
+		Aksi:      c.Query("aksi", ""),
+		Entitas:   c.Query("entitas", ""),
+		EntitasID: c.Query("entitas_id", ""),
+		Limit:     int64(limit),
+	})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil audit log"})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": list})
+}
+
+// recordAudit menyimpan satu catatan audit dengan pelaku dari token. Kegagalan
+// hanya dicatat di log agar aksi utamanya tidak ikut gagal. Aksinya sudah terjadi,
+// sehingga audit tetap ditulis walaupun request dibatalkan.
+func recordAudit(c *fiber.Ctx, repo repository.AuditRepository, timeout time.Duration, aksi, entitas, entitasID string, detail map[string]interface{}) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.UserContext()), timeout)
+	defer cancel()
+
+	entry := &models.AuditLog{
+		Aksi:      aksi,
+		Entitas:   entitas,
+		EntitasID: entitasID,
+		Detail:    detail,
+	}
+	entry.UserID, _ = c.Locals("user_id").(string)
+	entry.Username, _ = c.Locals("username").(string)
+	entry.Role, _ = c.Locals("role").(string)
+
+	if err := repo.Create(ctx, entry); err != nil {
+		slog.ErrorContext(ctx, "Gagal mencatat audit", "aksi", aksi, "entitas", entitas, "entitas_id", entitasID, "err", err)
+	}
+}
@@ -1,12 +1,12 @@
 package service
 
 import (
-	"context"
 	"errors"
-	"time"
 
 	models "crud-app/app/model"
 	"crud-app/app/repository"
+	"crud-app/config"
+	"crud-app/metrics"
 	"crud-app/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,11 +14,12 @@ import (
 )
 
 type AuthService struct {
-	repo repository.AuthRepository
+	repo     repository.AuthRepository
+	timeouts config.Timeouts
 }
 
-func NewAuthService(r repository.AuthRepository) *AuthService {
-	return &AuthService{repo: r}
+func NewAuthService(r repository.AuthRepository, timeouts config.Timeouts) *AuthService {
+	return &AuthService{repo: r, timeouts: timeouts}
 }
 
 // @Summary Login user
@@ -33,7 +34,7 @@ func NewAuthService(r repository.AuthRepository) *AuthService {
 // @Security Bearer
 // @Router /api/login [post]
 func (s *AuthService) Login(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := requestContext(c, s.timeouts.Read)
 	defer cancel()
 
 	var req models.LoginRequest
@@ -54,6 +55,7 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 	user, passwordHash, err := s.repo.GetByUsername(ctx, req.Username)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) || user == nil {
+			metrics.LoginAttempt(metrics.LoginFailure)
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error": "Username atau password salah",
 			})
@@ -65,6 +67,7 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 
 	// Cek password hash
 	if !utils.CheckPasswordHash(req.Password, passwordHash) {
+		metrics.LoginAttempt(metrics.LoginFailure)
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"error": "Username atau password Hash salah",
 		})
@@ -78,6 +81,8 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 		})
 	}
 
+	metrics.LoginAttempt(metrics.LoginSuccess)
+
 	response := models.LoginResponse{
 		User:  *user,
 		Token: token,
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"time"
+
+	"crud-app/tracing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// requestContext membuat context operasi dari context request (c.UserContext) dengan batas
+// waktu dari konfigurasi, sehingga query ikut berhenti begitu request dibatalkan. Context
+// juga membawa span bernama method service pemanggil (mis. AlumniService.GetWithoutPekerjaan)
+// yang diakhiri bersama cancel, sehingga command MongoDB tercatat di bawah span service.
+func requestContext(c *fiber.Ctx, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, span := tracing.Start(c.UserContext(), callerName())
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		span.End()
+	}
+}
+
+// callerName mengembalikan nama method pemanggil requestContext tanpa path package,
+// mis. "crud-app/app/service.(*AlumniService).GetWithoutPekerjaan" menjadi
+// "AlumniService.GetWithoutPekerjaan"
+func callerName() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return "service"
+	}
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimPrefix(name, "service.")
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
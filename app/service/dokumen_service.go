@@ -0,0 +1,526 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	models "crud-app/app/model"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// @Summary Buat dokumen
+// @Description Membuat dokumen logis (foto_profil, ijazah, transkrip, sertifikat) yang menyimpan riwayat versi file. Nama opsional, untuk membedakan beberapa dokumen dengan jenis yang sama. Admin wajib mengisi user_id pemilik
+// @Tags Dokumen
+// @Accept json
+// @Produce json
+// @Param body body models.CreateDokumenRequest true "Dokumen"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/dokumen [post]
+func (s *fileService) CreateDokumen(c *fiber.Ctx) error {
+
+	var req models.CreateDokumenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid request body",
+		})
+	}
+	if _, ok := models.KategoriFileDokumen(req.Jenis); !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid jenis. Allowed: foto_profil, ijazah, transkrip, sertifikat",
+		})
+	}
+
+	ownerID, err := resolveUploadOwner(c, req.UserID)
+	if err != nil {
+		return uploadFailed(c, err)
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	dokumen := &models.Dokumen{
+		UserID: ownerID,
+		Jenis:  req.Jenis,
+		Nama:   strings.TrimSpace(req.Nama),
+	}
+	created, err := s.dokumenRepo.Create(ctx, dokumen)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to create document",
+		})
+	}
+	if !created {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"success": false,
+			"message": "Document with the same jenis and nama already exists",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": "Document created successfully",
+		"data":    dokumen,
+	})
+}
+
+// @Summary Daftar dokumen
+// @Description Daftar dokumen beserta versi aktifnya. User melihat miliknya sendiri, admin melihat semua atau memilih user lewat user_id
+// @Tags Dokumen
+// @Produce json
+// @Param user_id query string false "User ID (khusus admin)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/dokumen [get]
+func (s *fileService) ListDokumen(c *fiber.Ctx) error {
+
+	role, _ := c.Locals("role").(string)
+	userIDStr, _ := c.Locals("user_id").(string)
+	if role == "admin" {
+		userIDStr = c.Query("user_id")
+	}
+
+	var filter *primitive.ObjectID
+	if userIDStr != "" {
+		userID, err := primitive.ObjectIDFromHex(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"message": "Invalid user_id format",
+			})
+		}
+		filter = &userID
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	list, err := s.dokumenRepo.List(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to get documents",
+		})
+	}
+
+	var ids []primitive.ObjectID
+	for _, d := range list {
+		if d.CurrentFileID != nil {
+			ids = append(ids, *d.CurrentFileID)
+		}
+	}
+	files, err := loadFileResponses(ctx, s.repo, ids)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to get documents",
+		})
+	}
+	for i := range list {
+		if list[i].CurrentFileID != nil {
+			list[i].Current = files[*list[i].CurrentFileID]
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    list,
+	})
+}
+
+// @Summary Detail dokumen
+// @Description Detail dokumen beserta versi aktif dan seluruh riwayat versinya (admin semua dokumen, user hanya miliknya)
+// @Tags Dokumen
+// @Produce json
+// @Param id path string true "Dokumen ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/dokumen/{id} [get]
+func (s *fileService) GetDokumen(c *fiber.Ctx) error {
+
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	dokumen, status, msg := s.getDokumen(ctx, c)
+	if dokumen == nil {
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"message": msg,
+		})
+	}
+	if err := s.embedDokumenFiles(ctx, dokumen); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to get document files",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    dokumen,
+	})
+}
+
+// @Summary Riwayat versi dokumen
+// @Description Daftar versi dokumen dari yang terbaru, ditandai mana yang aktif
+// @Tags Dokumen
+// @Produce json
+// @Param id path string true "Dokumen ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/dokumen/{id}/versions [get]
+func (s *fileService) ListDokumenVersions(c *fiber.Ctx) error {
+
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	dokumen, status, msg := s.getDokumen(ctx, c)
+	if dokumen == nil {
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"message": msg,
+		})
+	}
+	if err := s.embedDokumenFiles(ctx, dokumen); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to get document files",
+		})
+	}
+
+	versions := dokumen.Versions
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Versi > versions[j].Versi })
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    versions,
+	})
+}
+
+// @Summary Tambah versi dokumen
+// @Description Menjadikan file yang sudah di-upload sebagai versi baru sekaligus versi aktif dokumen. File harus milik pemilik dokumen, kategorinya sesuai jenis dokumen dan sudah lolos pemindaian. Versi lama di luar batas DOKUMEN_MAX_VERSIONS dihapus beserta file-nya. Versi baru dokumen foto_profil otomatis menjadi foto profil alumni pemiliknya
+// @Tags Dokumen
+// @Accept json
+// @Produce json
+// @Param id path string true "Dokumen ID"
+// @Param body body models.TambahVersiDokumenRequest true "File"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/dokumen/{id}/versions [post]
+func (s *fileService) AddDokumenVersion(c *fiber.Ctx) error {
+
+	var req models.TambahVersiDokumenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid request body",
+		})
+	}
+	if req.FileID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "file_id is required",
+		})
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Upload)
+	defer cancel()
+
+	dokumen, status, msg := s.getDokumen(ctx, c)
+	if dokumen == nil {
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"message": msg,
+		})
+	}
+
+	file, err := s.repo.FindByID(ctx, req.FileID)
+	if err != nil || !canAccessFile(c, file) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "File not found",
+		})
+	}
+	if file.UserID == nil || *file.UserID != dokumen.UserID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "File must belong to the document owner",
+		})
+	}
+	if kategori, _ := models.KategoriFileDokumen(dokumen.Jenis); file.Kategori != kategori {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "File kategori must be " + kategori + " for document jenis " + dokumen.Jenis,
+		})
+	}
+	if fileQuarantined(c, file) {
+		return nil
+	}
+
+	username, _ := c.Locals("username").(string)
+	versi := models.DokumenVersi{
+		Versi:     dokumen.LatestVersi + 1,
+		FileID:    file.ID,
+		Catatan:   strings.TrimSpace(req.Catatan),
+		CreatedBy: username,
+		CreatedAt: time.Now(),
+	}
+	added, err := s.dokumenRepo.AddVersion(ctx, dokumen.ID, dokumen.LatestVersi, versi)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to add document version",
+		})
+	}
+	if !added {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"success": false,
+			"message": "File is already a version of this document or the document was changed, please retry",
+		})
+	}
+
+	dokumen.Versions = append(dokumen.Versions, versi)
+	dokumen.LatestVersi = versi.Versi
+	dokumen.CurrentFileID = &versi.FileID
+	s.syncFotoProfil(ctx, dokumen)
+	s.applyDokumenRetention(ctx, dokumen)
+
+	if err := s.embedDokumenFiles(ctx, dokumen); err != nil {
+		slog.ErrorContext(ctx, "Gagal memuat file dokumen", "dokumen_id", dokumen.ID.Hex(), "err", err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": "Document version added successfully",
+		"data":    dokumen,
+	})
+}
+
+// @Summary Rollback dokumen
+// @Description Menjadikan versi sebelumnya sebagai versi aktif. Riwayat versi tidak berubah
+// @Tags Dokumen
+// @Accept json
+// @Produce json
+// @Param id path string true "Dokumen ID"
+// @Param body body models.RollbackDokumenRequest true "Versi tujuan"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/dokumen/{id}/rollback [post]
+func (s *fileService) RollbackDokumen(c *fiber.Ctx) error {
+
+	var req models.RollbackDokumenRequest
+	if err := c.BodyParser(&req); err != nil || req.Versi <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "versi is required",
+		})
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	dokumen, status, msg := s.getDokumen(ctx, c)
+	if dokumen == nil {
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"message": msg,
+		})
+	}
+
+	var target *models.DokumenVersi
+	for i := range dokumen.Versions {
+		if dokumen.Versions[i].Versi == req.Versi {
+			target = &dokumen.Versions[i]
+		}
+	}
+	if target == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "Version not found",
+		})
+	}
+
+	file, err := s.repo.FindByID(ctx, target.FileID.Hex())
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "File of this version not found",
+		})
+	}
+	if fileQuarantined(c, file) {
+		return nil
+	}
+
+	updated, err := s.dokumenRepo.SetCurrent(ctx, dokumen.ID, target.FileID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to roll back document",
+		})
+	}
+	if !updated {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"success": false,
+			"message": "Version was removed, please retry",
+		})
+	}
+
+	dokumen.CurrentFileID = &target.FileID
+	s.syncFotoProfil(ctx, dokumen)
+
+	if err := s.embedDokumenFiles(ctx, dokumen); err != nil {
+		slog.ErrorContext(ctx, "Gagal memuat file dokumen", "dokumen_id", dokumen.ID.Hex(), "err", err)
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Document rolled back successfully",
+		"data":    dokumen,
+	})
+}
+
+// getDokumen mengambil dokumen dari parameter :id. Admin bisa mengakses semua dokumen,
+// user lain hanya miliknya. Jika gagal, status dan pesan error dikembalikan.
+func (s *fileService) getDokumen(ctx context.Context, c *fiber.Ctx) (*models.Dokumen, int, string) {
+	dokumen, err := s.dokumenRepo.GetByID(ctx, c.Params("id"))
+	if err != nil {
+		return nil, fiber.StatusInternalServerError, "Failed to get document"
+	}
+	if dokumen == nil {
+		return nil, fiber.StatusNotFound, "Document not found"
+	}
+
+	role, _ := c.Locals("role").(string)
+	userID, _ := c.Locals("user_id").(string)
+	if role != "admin" && dokumen.UserID.Hex() != userID {
+		return nil, fiber.StatusNotFound, "Document not found"
+	}
+	return dokumen, 0, ""
+}
+
+// embedDokumenFiles mengisi metadata file versi aktif dan setiap versi dokumen
+func (s *fileService) embedDokumenFiles(ctx context.Context, dokumen *models.Dokumen) error {
+	ids := make([]primitive.ObjectID, 0, len(dokumen.Versions))
+	for _, v := range dokumen.Versions {
+		ids = append(ids, v.FileID)
+	}
+	files, err := loadFileResponses(ctx, s.repo, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range dokumen.Versions {
+		v := &dokumen.Versions[i]
+		v.File = files[v.FileID]
+		v.Current = dokumen.CurrentFileID != nil && *dokumen.CurrentFileID == v.FileID
+	}
+	if dokumen.CurrentFileID != nil {
+		dokumen.Current = files[*dokumen.CurrentFileID]
+	}
+	return nil
+}
+
+// applyDokumenRetention menghapus versi tertua (selain versi aktif) beserta file-nya
+// jika jumlah versi melebihi batas. Kegagalan hanya dicatat di log.
+func (s *fileService) applyDokumenRetention(ctx context.Context, dokumen *models.Dokumen) {
+	excess := len(dokumen.Versions) - s.maxVersions
+	if s.maxVersions <= 0 || excess <= 0 {
+		return
+	}
+
+	versions := append([]models.DokumenVersi(nil), dokumen.Versions...)
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Versi < versions[j].Versi })
+
+	var expired []primitive.ObjectID
+	for _, v := range versions {
+		if len(expired) == excess {
+			break
+		}
+		if dokumen.CurrentFileID != nil && *dokumen.CurrentFileID == v.FileID {
+			continue
+		}
+		expired = append(expired, v.FileID)
+	}
+	if len(expired) == 0 {
+		return
+	}
+
+	removed, err := s.dokumenRepo.RemoveVersions(ctx, dokumen.ID, expired)
+	if err != nil || !removed {
+		slog.WarnContext(ctx, "Retensi versi dokumen dilewati", "dokumen_id", dokumen.ID.Hex(), "err", err)
+		return
+	}
+
+	kept := dokumen.Versions[:0]
+	for _, v := range dokumen.Versions {
+		if !containsObjectID(expired, v.FileID) {
+			kept = append(kept, v)
+		}
+	}
+	dokumen.Versions = kept
+
+	for _, id := range expired {
+		// File yang masih dipakai (foto profil, lampiran, versi dokumen lain) cukup dilepas dari dokumen ini
+		used, err := s.fileReferenced(ctx, id)
+		if err != nil {
+			slog.WarnContext(ctx, "Gagal mengecek referensi file versi lama dokumen", "file_id", id.Hex(), "err", err)
+			continue
+		}
+		if used {
+			continue
+		}
+		file, err := s.repo.FindByID(ctx, id.Hex())
+		if err != nil {
+			continue // file sudah dihapus
+		}
+		if err := s.removeFile(ctx, file); err != nil {
+			slog.WarnContext(ctx, "Gagal menghapus file versi lama dokumen", "file_id", id.Hex(), "dokumen_id", dokumen.ID.Hex(), "err", err)
+		}
+	}
+}
+
+// syncFotoProfil menjadikan versi aktif dokumen foto_profil sebagai foto profil
+// alumni pemiliknya. Kegagalan hanya dicatat di log.
+func (s *fileService) syncFotoProfil(ctx context.Context, dokumen *models.Dokumen) {
+	if dokumen.Jenis != models.JenisDokumenFotoProfil || dokumen.CurrentFileID == nil {
+		return
+	}
+	owner, err := s.authRepo.GetByID(ctx, dokumen.UserID)
+	if err != nil || owner == nil || owner.AlumniID == nil {
+		return
+	}
+	if err := s.alumniRepo.SetFotoFile(ctx, owner.AlumniID.Hex(), dokumen.CurrentFileID); err != nil {
+		slog.WarnContext(ctx, "Gagal mengatur foto profil alumni", "alumni_id", owner.AlumniID.Hex(), "err", err)
+	}
+}
+
+func containsObjectID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,416 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	models "crud-app/app/model"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Batas jumlah alumni dalam satu ekspor ZIP
+const maxExportAlumni = 500
+
+// Status baris manifest ekspor
+const (
+	exportStatusOK          = "ok"
+	exportStatusQuarantined = "skipped: quarantined"
+)
+
+// exportEntry adalah satu file dalam ekspor ZIP beserta alumni pemiliknya
+type exportEntry struct {
+	alumni  *models.Alumni
+	file    *models.File
+	zipPath string
+	status  string
+}
+
+// @Summary Ekspor dokumen alumni (ZIP)
+// @Description Mengunduh semua foto dan sertifikat milik sekumpulan alumni sebagai ZIP yang dibuat langsung dari storage. Alumni dipilih lewat alumni_id (dipisah koma) atau filter search / jurusan / angkatan / tahun_lulus (maks 500 alumni). File yang diikutkan adalah file milik akun user alumni, foto profil dan lampiran pekerjaannya, dengan aturan akses yang sama seperti daftar file: admin semua file, user lain hanya file miliknya. ZIP berisi manifest.csv yang memetakan file ke alumni dan nama aslinya
+// @Tags Files
+// @Produce application/zip
+// @Param alumni_id query string false "ID alumni, dipisah koma"
+// @Param search query string false "Cari nim, nama, jurusan, email, no_telepon atau alamat"
+// @Param jurusan query string false "Filter jurusan"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param kategori query string false "Hanya kategori file ini (foto atau sertifikat)"
+// @Success 200 {file} binary
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/export [get]
+func (s *fileService) ExportFiles(c *fiber.Ctx) error {
+
+	kategori := c.Query("kategori")
+	if kategori != "" && kategori != models.KategoriFileFoto && kategori != models.KategoriFileSertifikat {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid kategori. Allowed: foto, sertifikat",
+		})
+	}
+
+	filter := models.AlumniFilter{
+		Search:     c.Query("search"),
+		Jurusan:    c.Query("jurusan"),
+		Angkatan:   c.QueryInt("angkatan"),
+		TahunLulus: c.QueryInt("tahun_lulus"),
+	}
+	hasFilter := filter != (models.AlumniFilter{})
+
+	var alumniIDs []string
+	for _, id := range strings.Split(c.Query("alumni_id"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			alumniIDs = append(alumniIDs, id)
+		}
+	}
+
+	// Tanpa parameter, user alumni mengekspor dokumennya sendiri
+	role, _ := c.Locals("role").(string)
+	if role != "admin" && len(alumniIDs) == 0 && !hasFilter {
+		if own, _ := c.Locals("alumni_id").(string); own != "" {
+			alumniIDs = []string{own}
+		}
+	}
+	if len(alumniIDs) == 0 && !hasFilter {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "alumni_id or a filter (search, jurusan, angkatan, tahun_lulus) is required",
+		})
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Upload)
+	defer cancel()
+
+	alumniList, status, msg := s.exportAlumni(ctx, alumniIDs, filter)
+	if alumniList == nil {
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"message": msg,
+		})
+	}
+
+	entries, err := s.collectExportFiles(ctx, c, alumniList, kategori)
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal menyiapkan ekspor file", "err", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to prepare export",
+		})
+	}
+	if len(entries) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "No files found for the selected alumni",
+		})
+	}
+
+	if role == "admin" {
+		recordAudit(c, s.auditRepo, s.timeouts.Write, models.AuditAksiEksporFile, "files", "", map[string]interface{}{
+			"alumni_id": alumniIDs,
+			"filter":    filter,
+			"kategori":  kategori,
+			"alumni":    len(alumniList),
+			"files":     len(entries),
+		})
+	}
+
+	name := "dokumen-alumni-" + time.Now().Format("20060102-150405") + ".zip"
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, contentDisposition("attachment", name))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	// ZIP ditulis langsung ke response saat dikirim, tanpa disimpan dulu di memori atau disk.
+	// Writer berjalan setelah handler selesai, jadi c tidak boleh dipakai lagi di dalamnya.
+	// Context request sudah dibatalkan saat writer berjalan; seluruh ekspor dibatasi
+	// BATCH_TIMEOUT_SECONDS dan setiap file UPLOAD_TIMEOUT_SECONDS.
+	streamCtx := context.WithoutCancel(c.UserContext())
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(streamCtx, s.timeouts.Batch)
+		defer cancel()
+		if err := s.writeExportZip(ctx, w, entries); err != nil {
+			slog.WarnContext(ctx, "Ekspor ZIP terhenti", "err", err)
+		}
+	})
+	return nil
+}
+
+// exportAlumni mengambil alumni berdasarkan daftar ID atau filter. Jika gagal,
+// nil beserta status dan pesan error dikembalikan.
+func (s *fileService) exportAlumni(ctx context.Context, ids []string, filter models.AlumniFilter) ([]models.Alumni, int, string) {
+	if len(ids) > maxExportAlumni {
+		return nil, fiber.StatusBadRequest, fmt.Sprintf("Too many alumni (max %d)", maxExportAlumni)
+	}
+
+	if len(ids) == 0 {
+		list, err := s.alumniRepo.GetAlumniRepo(ctx, filter, "nim", "asc", maxExportAlumni+1, 0)
+		if err != nil {
+			return nil, fiber.StatusInternalServerError, "Failed to get alumni"
+		}
+		if len(list) > maxExportAlumni {
+			return nil, fiber.StatusBadRequest, fmt.Sprintf("Filter matches more than %d alumni, please narrow it", maxExportAlumni)
+		}
+		if list == nil {
+			list = []models.Alumni{}
+		}
+		return list, 0, ""
+	}
+
+	list := []models.Alumni{}
+	for _, id := range ids {
+		if _, err := primitive.ObjectIDFromHex(id); err != nil {
+			return nil, fiber.StatusBadRequest, "Invalid alumni_id format: " + id
+		}
+		alumni, err := s.alumniRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fiber.StatusInternalServerError, "Failed to get alumni"
+		}
+		if alumni != nil && !alumni.IsDeleted {
+			list = append(list, *alumni)
+		}
+	}
+	return list, 0, ""
+}
+
+// collectExportFiles mengumpulkan file setiap alumni: file milik akun user-nya, foto profil
+// dan lampiran pekerjaan. File yang tidak boleh diakses pemanggil tidak diikutkan.
+func (s *fileService) collectExportFiles(ctx context.Context, c *fiber.Ctx, alumniList []models.Alumni, kategori string) ([]exportEntry, error) {
+	byUser := map[primitive.ObjectID][]int{}
+	var userIDs []primitive.ObjectID
+	byFile := map[primitive.ObjectID][]int{}
+	var fileIDs []primitive.ObjectID
+	addFile := func(id primitive.ObjectID, i int) {
+		if _, ok := byFile[id]; !ok {
+			fileIDs = append(fileIDs, id)
+		}
+		byFile[id] = append(byFile[id], i)
+	}
+
+	// Lampiran pekerjaan semua alumni diambil dengan satu query lalu dikelompokkan per alumni
+	alumniIDs := make([]primitive.ObjectID, 0, len(alumniList))
+	byAlumni := map[primitive.ObjectID]int{}
+	for i, a := range alumniList {
+		alumniIDs = append(alumniIDs, a.ID)
+		byAlumni[a.ID] = i
+	}
+	pekerjaan, err := s.pekerjaanRepo.GetByAlumniIDs(ctx, alumniIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, a := range alumniList {
+		if a.UserID != nil {
+			if _, ok := byUser[*a.UserID]; !ok {
+				userIDs = append(userIDs, *a.UserID)
+			}
+			byUser[*a.UserID] = append(byUser[*a.UserID], i)
+		}
+		if a.FotoFileID != nil {
+			addFile(*a.FotoFileID, i)
+		}
+	}
+	for _, p := range pekerjaan {
+		i, ok := byAlumni[p.AlumniID]
+		if !ok {
+			continue
+		}
+		for _, l := range p.Lampiran {
+			addFile(l.FileID, i)
+		}
+	}
+
+	owned, err := s.repo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	linked, err := s.repo.FindByIDs(ctx, fileIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Satu file bisa muncul lewat beberapa jalur (milik user sekaligus lampiran), cukup sekali per alumni
+	seen := map[string]bool{}
+	var entries []exportEntry
+	add := func(file *models.File, i int) {
+		key := strconv.Itoa(i) + "/" + file.ID.Hex()
+		if seen[key] || !canAccessFile(c, file) || (kategori != "" && file.Kategori != kategori) {
+			return
+		}
+		seen[key] = true
+
+		alumni := &alumniList[i]
+		status := exportStatusOK
+		if file.Quarantined() {
+			status = exportStatusQuarantined
+		}
+		entries = append(entries, exportEntry{
+			alumni:  alumni,
+			file:    file,
+			zipPath: exportZipPath(alumni, file),
+			status:  status,
+		})
+	}
+	for i := range owned {
+		for _, a := range byUser[*owned[i].UserID] {
+			add(&owned[i], a)
+		}
+	}
+	for i := range linked {
+		for _, a := range byFile[linked[i].ID] {
+			add(&linked[i], a)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].alumni.NIM != entries[j].alumni.NIM {
+			return entries[i].alumni.NIM < entries[j].alumni.NIM
+		}
+		return entries[i].zipPath < entries[j].zipPath
+	})
+	return entries, nil
+}
+
+// writeExportZip menulis isi file dan manifest.csv ke ZIP. File yang gagal dibuka atau
+// dibaca dari storage sebelum entry-nya dibuat dilewati dan dicatat statusnya di manifest.
+func (s *fileService) writeExportZip(ctx context.Context, w io.Writer, entries []exportEntry) error {
+	zw := zip.NewWriter(w)
+
+	for i := range entries {
+		e := &entries[i]
+		if e.status != exportStatusOK {
+			continue
+		}
+		if err := s.writeExportEntry(ctx, zw, e); err != nil {
+			// Koneksi client putus atau isi file terputus di tengah entry menghentikan ekspor
+			if _, ok := err.(exportAbortError); ok {
+				return err
+			}
+			e.status = "error: " + err.Error()
+		}
+	}
+
+	manifest, err := zw.Create("manifest.csv")
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(manifest)
+	cw.Write([]string{"alumni_id", "nim", "nama", "file_id", "kategori", "original_name", "zip_path", "file_size", "sha256", "uploaded_at", "status"})
+	for _, e := range entries {
+		zipPath := e.zipPath
+		if e.status != exportStatusOK {
+			zipPath = ""
+		}
+		cw.Write([]string{
+			e.alumni.ID.Hex(),
+			e.alumni.NIM,
+			e.alumni.Nama,
+			e.file.ID.Hex(),
+			e.file.Kategori,
+			e.file.OriginalName,
+			zipPath,
+			strconv.FormatInt(e.file.FileSize, 10),
+			e.file.SHA256,
+			e.file.UploadedAt.Format(time.RFC3339),
+			e.status,
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// exportAbortError menandai kegagalan yang tidak bisa dilewati: menulis ZIP ke client gagal,
+// atau isi file putus setelah entry-nya mulai ditulis sehingga ZIP tidak bisa dilanjutkan
+type exportAbortError struct{ error }
+
+// exportWriter menandai error dari sisi tulis agar bisa dibedakan dari error membaca storage
+type exportWriter struct{ w io.Writer }
+
+func (w exportWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if err != nil {
+		err = exportAbortError{err}
+	}
+	return n, err
+}
+
+func (s *fileService) writeExportEntry(ctx context.Context, zw *zip.Writer, e *exportEntry) error {
+	backend, err := s.storage.Backend(e.file.StorageBackend)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Upload)
+	defer cancel()
+	content, _, err := backend.Get(ctx, e.file.StorageKey)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	// Awal isi dibaca sebelum entry dibuat, sehingga file yang tidak bisa dibaca dilewati
+	// tanpa meninggalkan entry setengah jadi di ZIP
+	src := bufio.NewReader(content)
+	if _, err := src.Peek(1); err != nil && err != io.EOF {
+		return err
+	}
+
+	// Foto sudah terkompresi, hanya PDF yang di-deflate
+	method := zip.Deflate
+	if strings.HasPrefix(e.file.FileType, "image/") {
+		method = zip.Store
+	}
+	dst, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     e.zipPath,
+		Method:   method,
+		Modified: e.file.UploadedAt,
+	})
+	if err != nil {
+		return exportAbortError{err}
+	}
+	if _, err := io.Copy(exportWriter{dst}, src); err != nil {
+		if _, ok := err.(exportAbortError); ok {
+			return err
+		}
+		return exportAbortError{fmt.Errorf("membaca %s: %w", e.file.ID.Hex(), err)}
+	}
+	return nil
+}
+
+// exportZipPath menyusun path file di dalam ZIP: <nim>_<nama>/<kategori>/<file id>_<nama asli>
+func exportZipPath(alumni *models.Alumni, file *models.File) string {
+	folder := zipPathPart(alumni.NIM + "_" + alumni.Nama)
+	kategori := zipPathPart(file.Kategori)
+	if kategori == "_" {
+		kategori = "lainnya"
+	}
+	return folder + "/" + kategori + "/" + file.ID.Hex() + "_" + zipPathPart(file.OriginalName)
+}
+
+// zipPathPart membersihkan satu bagian path agar tidak membuat folder baru atau keluar dari ZIP
+func zipPathPart(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	name = strings.Trim(name, ".")
+	if name == "" {
+		return "_"
+	}
+	return name
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"crud-app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Batas masa berlaku signed link
+const (
+	defaultLinkExpiry = time.Hour
+	maxLinkExpiry     = 7 * 24 * time.Hour
+)
+
+// @Summary Buat signed link file
+// @Description Membuat URL unduh bertanda tangan HMAC yang bisa dipakai tanpa Bearer token, mis. untuk <img> di frontend atau link di laporan PDF. Link terikat ke file dan masa berlaku; dengan bind_user=true link juga terikat ke user pembuatnya dan hanya berlaku selama user tersebut masih ada dan masih boleh mengakses file
+// @Tags Files
+// @Produce json
+// @Param id path string true "File ID"
+// @Param expires query int false "Masa berlaku dalam detik (default 3600, maks 604800)"
+// @Param bind_user query bool false "Ikat link ke user yang membuatnya"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/{id}/link [get]
+func (s *fileService) CreateFileLink(c *fiber.Ctx) error {
+
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	file, err := s.repo.FindByID(ctx, c.Params("id"))
+	if err != nil || !canAccessFile(c, file) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "File not found",
+		})
+	}
+
+	if fileQuarantined(c, file) {
+		return nil
+	}
+
+	expiry := time.Duration(c.QueryInt("expires", int(defaultLinkExpiry/time.Second))) * time.Second
+	if expiry <= 0 {
+		expiry = defaultLinkExpiry
+	}
+	if expiry > maxLinkExpiry {
+		expiry = maxLinkExpiry
+	}
+	expiresAt := time.Now().Add(expiry)
+
+	userID := ""
+	if c.QueryBool("bind_user", false) {
+		userID, _ = c.Locals("user_id").(string)
+	}
+
+	fileID := file.ID.Hex()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	if userID != "" {
+		query.Set("user_id", userID)
+	}
+	query.Set("signature", utils.SignFileLink(s.linkSecret, fileID, expiresAt.Unix(), userID))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"url":        c.BaseURL() + "/files/signed/" + fileID + "?" + query.Encode(),
+			"expires_at": expiresAt.Truncate(time.Second),
+			"user_id":    userID,
+		},
+	})
+}
+
+// @Summary Download file lewat signed link
+// @Description Mengunduh file dengan URL dari /files/{id}/link tanpa Bearer token. Parameter size dan inline boleh ditambahkan ke URL seperti pada /files/{id}/download
+// @Tags Files
+// @Produce octet-stream
+// @Param id path string true "File ID"
+// @Param expires query int true "Waktu kedaluwarsa (unix)"
+// @Param user_id query string false "User yang terikat ke link"
+// @Param signature query string true "Tanda tangan HMAC"
+// @Param inline query bool false "Tampilkan di browser (Content-Disposition inline)"
+// @Param size query string false "Ukuran thumbnail foto (small, medium, large)"
+// @Success 200 {file} binary
+// @Success 206 {file} binary "Partial content"
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 410 {object} map[string]interface{}
+// @Router /files/signed/{id} [get]
+func (s *fileService) DownloadSignedFile(c *fiber.Ctx) error {
+
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	fileID := c.Params("id")
+	userID := c.Query("user_id")
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid link",
+		})
+	}
+
+	err = utils.VerifyFileLink(s.linkSecret, fileID, expires, userID, c.Query("signature"), time.Now())
+	if errors.Is(err, utils.ErrLinkExpired) {
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{
+			"success": false,
+			"message": "Link has expired",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid link",
+		})
+	}
+
+	file, err := s.repo.FindByID(ctx, fileID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "File not found",
+		})
+	}
+
+	// Link yang terikat user tidak berlaku lagi jika user dihapus atau kehilangan akses ke file
+	if userID != "" {
+		status, ok := s.linkUserAllowed(ctx, userID, file.UserID)
+		if !ok {
+			return c.Status(status).JSON(fiber.Map{
+				"success": false,
+				"message": "Link is no longer valid for this user",
+			})
+		}
+	}
+
+	return s.sendFile(c, file)
+}
+
+// linkUserAllowed mengecek apakah user yang terikat ke signed link masih boleh
+// mengakses file dengan pemilik ownerID (aturan sama dengan canAccessFile)
+func (s *fileService) linkUserAllowed(ctx context.Context, userID string, ownerID *primitive.ObjectID) (int, bool) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return fiber.StatusForbidden, false
+	}
+
+	user, err := s.authRepo.GetByID(ctx, objID)
+	if err != nil {
+		return fiber.StatusInternalServerError, false
+	}
+	if user == nil {
+		return fiber.StatusForbidden, false
+	}
+	if user.Role == "admin" || (ownerID != nil && *ownerID == objID) {
+		return 0, true
+	}
+	return fiber.StatusForbidden, false
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	models "crud-app/app/model"
+	"crud-app/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Objek yatim yang dikarantina dipindah ke awalan ini dan tidak ikut direkonsiliasi lagi
+const quarantinePrefix = "quarantine/"
+
+// Objek yang lebih baru dari batas ini dilewati karena bisa jadi upload yang metadatanya belum tersimpan
+const defaultReconcileMinAge = 60 // menit
+
+// @Summary Rekonsiliasi storage dan metadata file
+// @Description Mencari objek di storage yang tidak punya dokumen files (objek yatim) dan dokumen files yang isinya tidak ada di storage. action=report hanya melaporkan; action=quarantine memindah objek yatim ke quarantine/ dan menandai file hilang (missing_at) sehingga tidak bisa diunduh; action=delete menghapus objek yatim dan metadata file yang hilang beserta referensinya (admin only)
+// @Tags Files
+// @Produce json
+// @Param action query string false "report (default), quarantine atau delete"
+// @Param min_age query int false "Umur minimal objek yatim dalam menit (default 60)"
+// @Success 200 {object} models.ReconcileResult
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/reconcile [post]
+func (s *fileService) ReconcileFiles(c *fiber.Ctx) error {
+
+	action := c.Query("action", models.ReconcileReport)
+	if !models.IsReconcileActionValid(action) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid action. Allowed: report, quarantine, delete",
+		})
+	}
+	minAge := c.QueryInt("min_age", defaultReconcileMinAge)
+	if minAge < 0 {
+		minAge = defaultReconcileMinAge
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Batch)
+	defer cancel()
+
+	result, err := s.reconcile(ctx, action, minAge)
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal rekonsiliasi file", "err", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to reconcile files",
+		})
+	}
+
+	slog.InfoContext(ctx, "Rekonsiliasi file selesai", "action", action,
+		"orphan_objects", len(result.OrphanObjects), "missing_files", len(result.MissingFiles), "errors", len(result.Errors))
+	if action != models.ReconcileReport {
+		recordAudit(c, s.auditRepo, s.timeouts.Write, models.AuditAksiRekonsiliasi, "files", "", map[string]interface{}{
+			"action":         action,
+			"orphan_objects": len(result.OrphanObjects),
+			"missing_files":  len(result.MissingFiles),
+			"restored_files": len(result.RestoredFiles),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// reconcile membandingkan isi semua backend storage dengan collection files
+func (s *fileService) reconcile(ctx context.Context, action string, minAge int) (*models.ReconcileResult, error) {
+	result := &models.ReconcileResult{
+		Action:        action,
+		MinAgeMinutes: minAge,
+		StartedAt:     time.Now(),
+		OrphanObjects: []models.OrphanObject{},
+		MissingFiles:  []models.MissingFile{},
+	}
+
+	files, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.CheckedFiles = len(files)
+
+	// Key yang dirujuk dokumen files per backend, termasuk thumbnail
+	referenced := map[string]map[string]bool{}
+	for _, f := range files {
+		keys := referenced[f.StorageBackend]
+		if keys == nil {
+			keys = map[string]bool{}
+			referenced[f.StorageBackend] = keys
+		}
+		keys[f.StorageKey] = true
+		for _, t := range f.Thumbnails {
+			keys[t.StorageKey] = true
+		}
+	}
+
+	// 🔹 Dokumen files yang isinya tidak ada di storage
+	for i := range files {
+		f := &files[i]
+		backend, err := s.storage.Backend(f.StorageBackend)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("file %s: %v", f.ID.Hex(), err))
+			continue
+		}
+
+		_, err = backend.Stat(ctx, f.StorageKey)
+		if err == nil {
+			if f.MissingAt != nil {
+				result.RestoredFiles = append(result.RestoredFiles, f.ID.Hex())
+				if action != models.ReconcileReport {
+					if err := s.repo.SetMissing(ctx, f.ID, nil); err != nil {
+						result.Errors = append(result.Errors, fmt.Sprintf("file %s: %v", f.ID.Hex(), err))
+					}
+				}
+			}
+			continue
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			result.Errors = append(result.Errors, fmt.Sprintf("file %s: %v", f.ID.Hex(), err))
+			continue
+		}
+
+		missing := models.MissingFile{
+			FileID:       f.ID.Hex(),
+			OriginalName: f.OriginalName,
+			Backend:      f.StorageBackend,
+			StorageKey:   f.StorageKey,
+		}
+		if f.UserID != nil {
+			missing.UserID = f.UserID.Hex()
+		}
+		if action != models.ReconcileReport {
+			if err := s.fixMissingFile(ctx, f, action); err != nil {
+				missing.Error = err.Error()
+			} else {
+				missing.Fixed = true
+			}
+		}
+		result.MissingFiles = append(result.MissingFiles, missing)
+	}
+
+	// 🔹 Objek di storage tanpa dokumen files. Dikumpulkan dulu, baru diperbaiki
+	// setelah listing selesai agar penelusuran tidak melihat objek yang dipindah.
+	cutoff := result.StartedAt.Add(-time.Duration(minAge) * time.Minute)
+	for _, backend := range s.storage.Backends() {
+		var orphans []models.OrphanObject
+		err := backend.List(ctx, "", func(obj storage.ObjectInfo) error {
+			result.ScannedObjects++
+			if strings.HasPrefix(obj.Key, quarantinePrefix) || referenced[backend.Name()][obj.Key] || obj.ModTime.After(cutoff) {
+				return nil
+			}
+			orphans = append(orphans, models.OrphanObject{
+				Backend: backend.Name(),
+				Key:     obj.Key,
+				Size:    obj.Size,
+				ModTime: obj.ModTime,
+			})
+			return nil
+		})
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("storage %s: %v", backend.Name(), err))
+		}
+
+		for i := range orphans {
+			if action == models.ReconcileReport {
+				continue
+			}
+			if err := fixOrphanObject(ctx, backend, orphans[i].Key, action); err != nil {
+				orphans[i].Error = err.Error()
+			} else {
+				orphans[i].Fixed = true
+			}
+		}
+		result.OrphanObjects = append(result.OrphanObjects, orphans...)
+	}
+
+	result.FinishedAt = time.Now()
+	return result, nil
+}
+
+// fixMissingFile menandai file yang isinya hilang (quarantine) atau menghapus
+// metadatanya beserta foto profil / lampiran / versi dokumen yang merujuknya (delete)
+func (s *fileService) fixMissingFile(ctx context.Context, file *models.File, action string) error {
+	if action == models.ReconcileQuarantine {
+		if file.MissingAt != nil {
+			return nil
+		}
+		now := time.Now()
+		return s.repo.SetMissing(ctx, file.ID, &now)
+	}
+
+	// Referensi blob ikut dilepas; thumbnail yang masih tersisa terhapus jika tidak dipakai lagi
+	return s.removeFile(ctx, file)
+}
+
+// fixOrphanObject memindah objek yatim ke quarantine/ atau menghapusnya
+func fixOrphanObject(ctx context.Context, backend storage.Storage, key, action string) error {
+	if action == models.ReconcileQuarantine {
+		content, info, err := backend.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		_, err = backend.Put(ctx, quarantinePrefix+key, content, info.Size, info.ContentType)
+		content.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return backend.Delete(ctx, key)
+}
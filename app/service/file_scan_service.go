@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	models "crud-app/app/model"
+	"crud-app/scanner"
+	"crud-app/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Summary Pindai ulang file
+// @Description Memindai ulang isi file dengan scanner malware yang aktif (SCANNER_DRIVER), mis. untuk file yang masih pending karena scanner sempat tidak tersedia. File yang terinfeksi tetap dikarantina dan dicatat di audit log (admin only)
+// @Tags Files
+// @Produce json
+// @Param id path string true "File ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/{id}/scan [post]
+func (s *fileService) ScanFile(c *fiber.Ctx) error {
+
+	ctx, cancel := requestContext(c, s.timeouts.Batch)
+	defer cancel()
+
+	file, err := s.repo.FindByID(ctx, c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "File not found",
+		})
+	}
+
+	backend, err := s.storage.Backend(file.StorageBackend)
+	if err != nil {
+		slog.ErrorContext(ctx, "Backend storage file tidak tersedia", "file_id", file.ID.Hex(), "err", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Storage backend not available",
+		})
+	}
+
+	result, err := s.scanStoredFile(ctx, backend, file)
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal memindai file", "file_id", file.ID.Hex(), "scanner", s.scanner.Name(), "err", err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"success": false,
+			"message": "Malware scanner not available, file stays quarantined",
+		})
+	}
+	if !result.Clean {
+		s.reportInfected(c, file, result.Signature)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    newFileResponse(file),
+	})
+}
+
+// scanUpload memindai file yang baru disimpan. File bersih dilepas dari karantina;
+// file terinfeksi dihapus dan ditolak (422). Jika scanner tidak tersedia file tetap
+// dikarantina (pending) sampai dipindai ulang lewat POST /files/{id}/scan.
+func (s *fileService) scanUpload(ctx context.Context, c *fiber.Ctx, backend storage.Storage, file *models.File) error {
+	result, err := s.scanStoredFile(ctx, backend, file)
+	if err != nil {
+		slog.WarnContext(ctx, "File belum dipindai", "file_id", file.ID.Hex(), "scanner", s.scanner.Name(), "err", err)
+		return nil
+	}
+	if result.Clean {
+		return nil
+	}
+
+	s.reportInfected(c, file, result.Signature)
+	if err := s.repo.Delete(ctx, file.ID.Hex()); err != nil {
+		slog.ErrorContext(ctx, "Gagal menghapus metadata file terinfeksi", "file_id", file.ID.Hex(), "err", err)
+	}
+	s.releaseFile(ctx, file)
+	return newUploadError(fiber.StatusUnprocessableEntity, fmt.Sprintf("File rejected: malware detected (%s)", result.Signature))
+}
+
+// scanStoredFile membaca isi file dari storage, memindainya lalu mencatat hasilnya
+// di file (dan database). File terinfeksi hasil dedup dikarantina bersama semua file lain
+// dengan hash yang sama karena isinya satu objek. Error berarti file belum bisa dipindai.
+func (s *fileService) scanStoredFile(ctx context.Context, backend storage.Storage, file *models.File) (scanner.Result, error) {
+	content, _, err := backend.Get(ctx, file.StorageKey)
+	if err != nil {
+		return scanner.Result{}, err
+	}
+	defer content.Close()
+
+	result, err := s.scanner.Scan(ctx, content)
+	if err != nil {
+		return scanner.Result{}, err
+	}
+
+	status := models.ScanStatusClean
+	if !result.Clean {
+		status = models.ScanStatusInfected
+	}
+	if !result.Clean && file.SHA256 != "" {
+		err = s.repo.UpdateScanStatusBySHA256(ctx, file.SHA256, status, result.Signature)
+	} else {
+		err = s.repo.UpdateScanStatus(ctx, file.ID, status, result.Signature)
+	}
+	if err != nil {
+		return scanner.Result{}, err
+	}
+
+	now := time.Now()
+	file.ScanStatus = status
+	file.ScanSignature = result.Signature
+	file.ScannedAt = &now
+	return result, nil
+}
+
+// reportInfected mencatat file terinfeksi di log dan audit log
+func (s *fileService) reportInfected(c *fiber.Ctx, file *models.File, signature string) {
+	slog.WarnContext(c.UserContext(), "Malware terdeteksi", "file_id", file.ID.Hex(), "scanner", s.scanner.Name(), "signature_malware", signature)
+
+	detail := map[string]interface{}{
+		"original_name": file.OriginalName,
+		"file_type":     file.FileType,
+		"file_size":     file.FileSize,
+		"scanner":       s.scanner.Name(),
+		"signature":     signature,
+	}
+	if file.UserID != nil {
+		detail["owner_id"] = file.UserID.Hex()
+	}
+	recordAudit(c, s.auditRepo, s.timeouts.Write, models.AuditAksiFileTerinfeksi, "files", file.ID.Hex(), detail)
+}
+
+// fileQuarantined mengirim 409 jika file belum lolos pemindaian malware atau
+// isinya ditandai hilang oleh rekonsiliasi
+func fileQuarantined(c *fiber.Ctx, file *models.File) bool {
+	if !file.Quarantined() {
+		return false
+	}
+	message := "File is quarantined until the malware scan is clean"
+	if file.MissingAt != nil {
+		message = "File content is missing from storage"
+	}
+	c.Status(fiber.StatusConflict).JSON(fiber.Map{
+		"success": false,
+		"message": message,
+	})
+	return true
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/scanner"
+	"crud-app/storage"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeFileRepo menyimpan metadata file di memori; method yang tidak dipakai test panic
+type fakeFileRepo struct {
+	repository.FileRepository
+
+	mu    sync.Mutex
+	files map[primitive.ObjectID]*models.File
+}
+
+func newFakeFileRepo(files ...*models.File) *fakeFileRepo {
+	r := &fakeFileRepo{files: map[primitive.ObjectID]*models.File{}}
+	for _, f := range files {
+		stored := *f
+		r.files[f.ID] = &stored
+	}
+	return r
+}
+
+func (r *fakeFileRepo) get(id primitive.ObjectID) models.File {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.files[id]
+}
+
+func (r *fakeFileRepo) UpdateScanStatus(ctx context.Context, id primitive.ObjectID, status, signature string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.files[id]; ok {
+		f.ScanStatus, f.ScanSignature = status, signature
+	}
+	return nil
+}
+
+func (r *fakeFileRepo) UpdateScanStatusBySHA256(ctx context.Context, sha256, status, signature string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, f := range r.files {
+		if f.SHA256 == sha256 {
+			f.ScanStatus, f.ScanSignature = status, signature
+		}
+	}
+	return nil
+}
+
+// fakeScanner menandai isi yang mengandung marker sebagai malware
+type fakeScanner struct {
+	marker    string
+	signature string
+}
+
+func (s fakeScanner) Name() string {
+	return "fake"
+}
+
+func (s fakeScanner) Scan(ctx context.Context, r io.Reader) (scanner.Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return scanner.Result{}, err
+	}
+	if strings.Contains(string(data), s.marker) {
+		return scanner.Result{Signature: s.signature}, nil
+	}
+	return scanner.Result{Clean: true}, nil
+}
+
+func newTestStorage(t *testing.T) *storage.Manager {
+	t.Helper()
+	local, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return storage.NewManager(local)
+}
+
+func putObject(t *testing.T, backend storage.Storage, key, content string) {
+	t.Helper()
+	if _, err := backend.Put(context.Background(), key, strings.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanStoredFileQuarantinesDedupedCopies(t *testing.T) {
+	const hash = "ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12"
+	store := newTestStorage(t)
+	backend := store.Default()
+	key := blobKey(hash, primitive.NewObjectID().Hex(), ".pdf")
+	putObject(t, backend, key, "%PDF-1.4 EICAR")
+
+	// Dua file milik user berbeda dengan isi sama memakai satu objek storage
+	first := &models.File{ID: primitive.NewObjectID(), SHA256: hash, StorageBackend: backend.Name(), StorageKey: key, ScanStatus: models.ScanStatusClean}
+	second := &models.File{ID: primitive.NewObjectID(), SHA256: hash, StorageBackend: backend.Name(), StorageKey: key, ScanStatus: models.ScanStatusClean}
+	other := &models.File{ID: primitive.NewObjectID(), SHA256: strings.Repeat("0", 64), StorageBackend: backend.Name(), StorageKey: "other.pdf", ScanStatus: models.ScanStatusClean}
+	repo := newFakeFileRepo(first, second, other)
+
+	s := &fileService{
+		repo:    repo,
+		storage: store,
+		scanner: fakeScanner{marker: "EICAR", signature: "Eicar-Test-Signature"},
+	}
+
+	result, err := s.scanStoredFile(context.Background(), backend, first)
+	if err != nil {
+		t.Fatalf("scanStoredFile: %v", err)
+	}
+	if result.Clean {
+		t.Fatal("file seharusnya terdeteksi malware")
+	}
+
+	for _, f := range []*models.File{first, second} {
+		stored := repo.get(f.ID)
+		if stored.ScanStatus != models.ScanStatusInfected || !stored.Quarantined() {
+			t.Errorf("file %s: scan_status = %q, seharusnya dikarantina (infected)", f.ID.Hex(), stored.ScanStatus)
+		}
+		if stored.ScanSignature != "Eicar-Test-Signature" {
+			t.Errorf("file %s: scan_signature = %q", f.ID.Hex(), stored.ScanSignature)
+		}
+	}
+	if stored := repo.get(other.ID); stored.ScanStatus != models.ScanStatusClean {
+		t.Errorf("file dengan hash lain ikut berubah: scan_status = %q", stored.ScanStatus)
+	}
+}
@@ -1,15 +1,27 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
 	"path/filepath"
+	"strings"
+	"time"
 
 	models "crud-app/app/model"
 	"crud-app/app/repository"
+	"crud-app/config"
+	"crud-app/metrics"
+	"crud-app/scanner"
+	"crud-app/storage"
+	"crud-app/utils"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -18,23 +30,97 @@ type FileService interface {
 	UploadSertifikat(c *fiber.Ctx) error
 	GetAllFiles(c *fiber.Ctx) error
 	GetFileByID(c *fiber.Ctx) error
+	DownloadFile(c *fiber.Ctx) error
+	PresignFile(c *fiber.Ctx) error
+	CreateFileLink(c *fiber.Ctx) error
+	DownloadSignedFile(c *fiber.Ctx) error
 	DeleteFile(c *fiber.Ctx) error
+	ScanFile(c *fiber.Ctx) error
+	ReconcileFiles(c *fiber.Ctx) error
+	ExportFiles(c *fiber.Ctx) error
+	CreateDokumen(c *fiber.Ctx) error
+	ListDokumen(c *fiber.Ctx) error
+	GetDokumen(c *fiber.Ctx) error
+	ListDokumenVersions(c *fiber.Ctx) error
+	AddDokumenVersion(c *fiber.Ctx) error
+	RollbackDokumen(c *fiber.Ctx) error
+	TusOptions(c *fiber.Ctx) error
+	TusCreate(c *fiber.Ctx) error
+	TusHead(c *fiber.Ctx) error
+	TusPatch(c *fiber.Ctx) error
+	TusDelete(c *fiber.Ctx) error
+	GetUsage(c *fiber.Ctx) error
+	GetTopUsage(c *fiber.Ctx) error
+	SetQuota(c *fiber.Ctx) error
+	DeleteQuota(c *fiber.Ctx) error
 }
 
 type fileService struct {
-	repo       repository.FileRepository
-	uploadPath string
+	repo          repository.FileRepository
+	blobRepo      repository.FileBlobRepository
+	dokumenRepo   repository.DokumenRepository
+	auditRepo     repository.AuditRepository
+	alumniRepo    repository.AlumniRepository
+	pekerjaanRepo repository.PekerjaanRepository
+	tusRepo       repository.TusUploadRepository
+	authRepo      repository.AuthRepository
+	quotaRepo     repository.StorageQuotaRepository
+	storage       *storage.Manager
+	scanner       scanner.Scanner
+	categories    map[string]uploadCategory
+	quotas        config.StorageQuotas
+	tusDir        string
+	linkSecret    []byte // kunci HMAC signed link
+	maxVersions   int    // batas versi per dokumen, 0 tanpa batas
+	timeouts      config.Timeouts
+	tusLocks      tusLockMap // kunci per upload tus agar PATCH tidak ditulis bersamaan
 }
 
-func NewFileService(repo repository.FileRepository, uploadPath string) FileService {
+// FileServiceDeps mengumpulkan repository, storage dan scanner yang dipakai FileService
+type FileServiceDeps struct {
+	Files      repository.FileRepository
+	Blobs      repository.FileBlobRepository
+	Dokumen    repository.DokumenRepository
+	Audit      repository.AuditRepository
+	Alumni     repository.AlumniRepository
+	Pekerjaan  repository.PekerjaanRepository
+	TusUploads repository.TusUploadRepository
+	Auth       repository.AuthRepository
+	Quotas     repository.StorageQuotaRepository
+	Storage    *storage.Manager
+	Scanner    scanner.Scanner
+}
+
+func NewFileService(deps FileServiceDeps, cfg config.UploadConfig, timeouts config.Timeouts) FileService {
 	return &fileService{
-		repo:       repo,
-		uploadPath: uploadPath,
+		repo:          deps.Files,
+		blobRepo:      deps.Blobs,
+		dokumenRepo:   deps.Dokumen,
+		auditRepo:     deps.Audit,
+		alumniRepo:    deps.Alumni,
+		pekerjaanRepo: deps.Pekerjaan,
+		tusRepo:       deps.TusUploads,
+		authRepo:      deps.Auth,
+		quotaRepo:     deps.Quotas,
+		storage:       deps.Storage,
+		scanner:       deps.Scanner,
+		categories:    newUploadCategories(cfg.Limits),
+		quotas:        cfg.Quotas,
+		tusDir:        cfg.TusDir,
+		linkSecret:    cfg.LinkSecret,
+		maxVersions:   cfg.DokumenMaxVersions,
+		timeouts:      timeouts,
 	}
 }
 
+// Batas masa berlaku presigned URL
+const (
+	defaultPresignExpiry = 15 * time.Minute
+	maxPresignExpiry     = 7 * 24 * time.Hour
+)
+
 // @Summary Upload foto (profile picture)
-// @Description Upload file foto dengan maksimal 1 MB
+// @Description Upload file foto (JPEG/PNG) dengan maksimal 1 MB (UPLOAD_MAX_FOTO_MB). Tipe file dideteksi dari isinya dan harus sama dengan Content-Type yang dikirim. Foto di-encode ulang tanpa EXIF, diputar sesuai orientasi, dikecilkan ke maks 1600px dan dibuatkan thumbnail small/medium/large
 // @Accept mpfd
 // @Tags Files
 // @Produce json
@@ -43,17 +129,16 @@ func NewFileService(repo repository.FileRepository, uploadPath string) FileServi
 // @Success 201 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 413 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Security Bearer
 // @Router /files/upload/foto [post]
 func (s *fileService) UploadFoto(c *fiber.Ctx) error {
-
-	allowedTypes := []string{"image/jpeg", "image/jpg", "image/png"}
-	return s.uploadHandler(c, allowedTypes, 1)
+	return s.uploadHandler(c, s.categories[models.KategoriFileFoto])
 }
 
 // @Summary Upload sertifikat (PDF)
-// @Description Upload file sertifikat dalam format PDF dengan maksimal 2 MB
+// @Description Upload file sertifikat dalam format PDF dengan maksimal 2 MB (UPLOAD_MAX_SERTIFIKAT_MB). Tipe file dideteksi dari isinya dan struktur PDF divalidasi. File dipindai malware dan dikarantina sampai hasilnya bersih; file terinfeksi ditolak (422). File lebih besar bisa di-upload lewat /files/tus/
 // @Tags Files
 // @Accept mpfd
 // @Produce json
@@ -62,13 +147,12 @@ func (s *fileService) UploadFoto(c *fiber.Ctx) error {
 // @Success 201 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 413 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Security Bearer
 // @Router /files/upload/sertifikat [post]
 func (s *fileService) UploadSertifikat(c *fiber.Ctx) error {
-
-	allowedTypes := []string{"application/pdf"}
-	return s.uploadHandler(c, allowedTypes, 2)
+	return s.uploadHandler(c, s.categories[models.KategoriFileSertifikat])
 }
 
 // @Summary Internal upload handler
@@ -76,127 +160,252 @@ func (s *fileService) UploadSertifikat(c *fiber.Ctx) error {
 // @Hidden
 // @Tags Files
 // @Security Bearer
-func (s *fileService) uploadHandler(c *fiber.Ctx, allowedTypes []string, maxSizeMB int64) error {
+func (s *fileService) uploadHandler(c *fiber.Ctx, category uploadCategory) error {
+
+	targetUserID, err := resolveUploadOwner(c, c.FormValue("user_id"))
+	if err != nil {
+		return uploadFailed(c, err)
+	}
+
+	// 🔹 Ambil file dari form
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "No file uploaded",
+		})
+	}
+
+	// 🔹 Validasi tipe file yang dideklarasikan client
+	declaredType := utils.NormalizeContentType(fileHeader.Header.Get("Content-Type"))
+	if err := category.checkType(declaredType); err != nil {
+		return uploadFailed(c, err)
+	}
+
+	// 🔹 Validasi ukuran file
+	if err := checkUploadSize(fileHeader.Size, category.maxSize); err != nil {
+		return uploadFailed(c, err)
+	}
+
+	src, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to read uploaded file",
+		})
+	}
+	defer src.Close()
+
+	fileModel, err := s.storeUpload(c, src, fileHeader.Size, declaredType, fileHeader.Filename, category, targetUserID)
+	if err != nil {
+		return uploadFailed(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": "File uploaded successfully",
+		"data":    newFileResponse(fileModel),
+	})
+}
+
+// storeUpload memvalidasi isi file, memproses foto, menyimpannya ke storage, mencatat
+// metadatanya lalu memindai malware. Dipakai upload multipart dan upload tus yang sudah
+// selesai. Error yang dikembalikan berupa *uploadError dengan status HTTP yang sesuai.
+func (s *fileService) storeUpload(c *fiber.Ctx, src io.ReadSeeker, srcSize int64, declaredType, originalName string, category uploadCategory, targetUserID primitive.ObjectID) (*models.File, error) {
+
+	ctx, cancel := requestContext(c, s.timeouts.Upload)
+	defer cancel()
 
+	// 🔹 Cek kuota pemilik file sebelum file diproses dan disimpan
+	if err := s.checkQuota(ctx, targetUserID, srcSize); err != nil {
+		return nil, err
+	}
+
+	// 🔹 Deteksi tipe dari isi file (magic bytes), harus sama dengan yang dideklarasikan
+	contentType, err := utils.DetectContentType(src)
+	if err != nil {
+		return nil, newUploadError(fiber.StatusBadRequest, "Failed to read uploaded file")
+	}
+	if contentType != declaredType {
+		return nil, newUploadError(fiber.StatusBadRequest, fmt.Sprintf("File content (%s) does not match declared type (%s)", contentType, declaredType))
+	}
+
+	// 🔹 Pastikan struktur file valid (header dan dimensi gambar, PDF punya header dan xref).
+	// Gambar di-decode penuh satu kali saat diproses di bawah.
+	if err := utils.ValidateFileStructure(src, srcSize, contentType); err != nil {
+		return nil, newUploadError(fiber.StatusBadRequest, err.Error())
+	}
+
+	// 🔹 Foto di-encode ulang: EXIF dibuang, orientasi diperbaiki, ukuran dibatasi, thumbnail dibuat
+	var body io.ReadSeeker = src
+	size := srcSize
+	var photo *utils.ProcessedImage
+	var thumbs map[string]*utils.ProcessedImage
+	if strings.HasPrefix(contentType, "image/") {
+		photo, thumbs, err = utils.ProcessPhoto(src, contentType)
+		if err != nil {
+			return nil, newUploadError(fiber.StatusBadRequest, err.Error())
+		}
+		body = bytes.NewReader(photo.Data)
+		size = int64(len(photo.Data))
+	}
+
+	// 🔹 Hash isi yang disimpan; file dengan isi sama memakai objek storage yang sama
+	hash, err := utils.HashSHA256(body)
+	if err != nil {
+		return nil, newUploadError(fiber.StatusBadRequest, "Failed to read uploaded file")
+	}
+	newFileName := hash + utils.ExtensionForContentType(contentType)
+
+	fileModel := &models.File{
+		UserID:       &targetUserID,
+		FileName:     newFileName,
+		OriginalName: originalName,
+		SHA256:       hash,
+		FileSize:     size,
+		FileType:     contentType,
+		Kategori:     category.name,
+		ScanStatus:   models.ScanStatusPending,
+	}
+	if photo != nil {
+		fileModel.Width, fileModel.Height = photo.Width, photo.Height
+	}
+	for _, v := range utils.PhotoVariants {
+		if thumb, ok := thumbs[v.Name]; ok {
+			fileModel.Thumbnails = append(fileModel.Thumbnails, models.FileThumbnail{
+				Size:     v.Name,
+				FileSize: int64(len(thumb.Data)),
+				Width:    thumb.Width,
+				Height:   thumb.Height,
+			})
+		}
+	}
+
+	// 🔹 Simpan isi file ke storage hanya jika belum ada (upload pertama dengan hash ini)
+	backend, err := s.storeBlob(ctx, fileModel, body, thumbs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🔹 Simpan metadata ke database, file masih dikarantina sampai lolos pemindaian
+	if err := s.repo.Create(ctx, fileModel); err != nil {
+		s.releaseFile(ctx, fileModel)
+		return nil, newUploadError(fiber.StatusInternalServerError, "Failed to save file metadata")
+	}
+
+	// 🔹 Pindai malware
+	if err := s.scanUpload(ctx, c, backend, fileModel); err != nil {
+		return nil, err
+	}
+
+	metrics.FileUploaded(category.name, fileModel.FileSize)
+	return fileModel, nil
+}
+
+// resolveUploadOwner menentukan pemilik file yang di-upload: user / alumni hanya untuk
+// dirinya sendiri, admin wajib menyebutkan user_id tujuan
+func resolveUploadOwner(c *fiber.Ctx, requestedUserID string) (primitive.ObjectID, error) {
 	roleVal := c.Locals("role")
 	userIDVal := c.Locals("user_id")
 
 	if roleVal == nil || userIDVal == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "Token tidak valid atau role tidak dikenali",
-		})
+		return primitive.NilObjectID, newUploadError(fiber.StatusUnauthorized, "Token tidak valid atau role tidak dikenali")
 	}
 
 	role, _ := roleVal.(string)
 	userIDStr := fmt.Sprintf("%v", userIDVal)
-	var targetUserID primitive.ObjectID
-	var err error
 
 	// 🔹 Role-based access logic
 	switch role {
 	case "user", "alumni":
-		formUserID := c.FormValue("user_id")
-		if formUserID != "" && formUserID != userIDStr {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Kamu tidak boleh upload file untuk user lain",
-			})
+		if requestedUserID != "" && requestedUserID != userIDStr {
+			return primitive.NilObjectID, newUploadError(fiber.StatusForbidden, "Kamu tidak boleh upload file untuk user lain")
 		}
-		targetUserID, _ = primitive.ObjectIDFromHex(userIDStr)
+		targetUserID, _ := primitive.ObjectIDFromHex(userIDStr)
+		return targetUserID, nil
 
 	case "admin":
-		targetUserIDHex := c.FormValue("user_id")
-		if targetUserIDHex == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "user_id is required for admin upload",
-			})
+		if requestedUserID == "" {
+			return primitive.NilObjectID, newUploadError(fiber.StatusBadRequest, "user_id is required for admin upload")
 		}
-		targetUserID, err = primitive.ObjectIDFromHex(targetUserIDHex)
+		targetUserID, err := primitive.ObjectIDFromHex(requestedUserID)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Invalid user_id format",
-			})
+			return primitive.NilObjectID, newUploadError(fiber.StatusBadRequest, "Invalid user_id format")
 		}
+		return targetUserID, nil
 
 	default:
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"message": fmt.Sprintf("Role '%v' tidak diizinkan upload", role),
-		})
+		return primitive.NilObjectID, newUploadError(fiber.StatusForbidden, fmt.Sprintf("Role '%v' tidak diizinkan upload", role))
 	}
+}
 
-	// 🔹 Ambil file dari form
-	fileHeader, err := c.FormFile("file")
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "No file uploaded",
-		})
+// uploadCategory adalah aturan upload per kategori file
+type uploadCategory struct {
+	name         string // nama kategori, sekaligus folder di storage
+	allowedTypes []string
+	maxSize      int64 // batas upload multipart (byte)
+	tusMaxSize   int64 // batas upload resumable / tus (byte)
+}
+
+func newUploadCategories(limits config.UploadLimits) map[string]uploadCategory {
+	const mb = 1024 * 1024
+	return map[string]uploadCategory{
+		models.KategoriFileFoto: {
+			name:         models.KategoriFileFoto,
+			allowedTypes: []string{"image/jpeg", "image/jpg", "image/png"},
+			maxSize:      limits.FotoMB * mb,
+			tusMaxSize:   limits.TusFotoMB * mb,
+		},
+		models.KategoriFileSertifikat: {
+			name:         models.KategoriFileSertifikat,
+			allowedTypes: []string{"application/pdf"},
+			maxSize:      limits.SertifikatMB * mb,
+			tusMaxSize:   limits.TusSertifikatMB * mb,
+		},
 	}
+}
 
-	// 🔹 Validasi tipe file
-	contentType := fileHeader.Header.Get("Content-Type")
-	allowed := false
-	for _, t := range allowedTypes {
-		if t == contentType {
-			allowed = true
-			break
+// checkType mengecek content type (sudah dinormalisasi) terhadap daftar yang diizinkan
+func (cat uploadCategory) checkType(contentType string) error {
+	for _, t := range cat.allowedTypes {
+		if utils.NormalizeContentType(t) == contentType {
+			return nil
 		}
 	}
-	if !allowed {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": fmt.Sprintf("Invalid file type. Allowed: %v", allowedTypes),
-		})
-	}
+	return newUploadError(fiber.StatusBadRequest, fmt.Sprintf("Invalid file type. Allowed: %v", cat.allowedTypes))
+}
 
-	// 🔹 Validasi ukuran file
-	maxSize := maxSizeMB * 1024 * 1024
-	if fileHeader.Size > maxSize {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": fmt.Sprintf("File too large (max %d MB)", maxSizeMB),
-		})
+func checkUploadSize(size, maxSize int64) error {
+	if size > maxSize {
+		return newUploadError(fiber.StatusBadRequest, fmt.Sprintf("File too large (max %d MB)", maxSize/(1024*1024)))
 	}
+	return nil
+}
 
-	// 🔹 Simpan file ke folder
-	ext := filepath.Ext(fileHeader.Filename)
-	newFileName := uuid.New().String() + ext
-	filePath := filepath.Join(s.uploadPath, newFileName)
-	os.MkdirAll(s.uploadPath, os.ModePerm)
+// uploadError membawa status HTTP dan pesan untuk client dari proses upload
+type uploadError struct {
+	status  int
+	message string
+}
 
-	if err := c.SaveFile(fileHeader, filePath); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to save file",
-		})
-	}
+func newUploadError(status int, message string) *uploadError {
+	return &uploadError{status: status, message: message}
+}
 
-	// 🔹 Simpan metadata ke database
-	fileModel := &models.File{
-		UserID:       &targetUserID,
-		FileName:     newFileName,
-		OriginalName: fileHeader.Filename,
-		FilePath:     filePath,
-		FileSize:     fileHeader.Size,
-		FileType:     contentType,
-	}
+func (e *uploadError) Error() string {
+	return e.message
+}
 
-	if err := s.repo.Create(fileModel); err != nil {
-		os.Remove(filePath)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to save file metadata",
-		})
+// uploadFailed mengirim error upload dengan format response yang sama untuk semua endpoint upload
+func uploadFailed(c *fiber.Ctx, err error) error {
+	status, message := fiber.StatusInternalServerError, "Failed to process upload"
+	var uerr *uploadError
+	if errors.As(err, &uerr) {
+		status, message = uerr.status, uerr.message
 	}
-
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"message": "File uploaded successfully",
-		"data":    s.toFileResponse(fileModel),
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"message": message,
 	})
 }
 
@@ -210,6 +419,9 @@ func (s *fileService) uploadHandler(c *fiber.Ctx, allowedTypes []string, maxSize
 // @Router /files [get]
 func (s *fileService) GetAllFiles(c *fiber.Ctx) error {
 
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
 	role := c.Locals("role").(string)
 	userIDStr := c.Locals("user_id").(string)
 
@@ -217,10 +429,10 @@ func (s *fileService) GetAllFiles(c *fiber.Ctx) error {
 	var err error
 
 	if role == "admin" {
-		files, err = s.repo.FindAll()
+		files, err = s.repo.FindAll(ctx)
 	} else {
 		userID, _ := primitive.ObjectIDFromHex(userIDStr)
-		files, err = s.repo.FindByUserID(userID)
+		files, err = s.repo.FindByUserID(ctx, userID)
 	}
 
 	if err != nil {
@@ -232,7 +444,7 @@ func (s *fileService) GetAllFiles(c *fiber.Ctx) error {
 
 	var responses []models.FileResponse
 	for _, f := range files {
-		responses = append(responses, *s.toFileResponse(&f))
+		responses = append(responses, *newFileResponse(&f))
 	}
 
 	return c.JSON(fiber.Map{
@@ -242,7 +454,7 @@ func (s *fileService) GetAllFiles(c *fiber.Ctx) error {
 }
 
 // @Summary Get file by ID
-// @Description Get detail file berdasarkan ID
+// @Description Get detail file berdasarkan ID (admin semua file, user hanya miliknya)
 // @Tags Files
 // @Produce json
 // @Param id path string true "File ID"
@@ -252,23 +464,241 @@ func (s *fileService) GetAllFiles(c *fiber.Ctx) error {
 // @Router /files/{id} [get]
 func (s *fileService) GetFileByID(c *fiber.Ctx) error {
 
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
 	id := c.Params("id")
-	file, err := s.repo.FindByID(id)
+	file, err := s.repo.FindByID(ctx, id)
+	if err != nil || !canAccessFile(c, file) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "File not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    newFileResponse(file),
+	})
+}
+
+// @Summary Download file
+// @Description Stream isi file dengan Content-Type dan nama asli. Mendukung Range (satu rentang), ETag / If-None-Match dan If-Range. Admin bisa mengunduh semua file, user hanya miliknya
+// @Tags Files
+// @Produce octet-stream
+// @Param id path string true "File ID"
+// @Param inline query bool false "Tampilkan di browser (Content-Disposition inline)"
+// @Param size query string false "Ukuran thumbnail foto (small, medium, large)"
+// @Param Range header string false "Rentang byte, mis. bytes=0-1023"
+// @Success 200 {file} binary
+// @Success 206 {file} binary "Partial content"
+// @Success 304 "Not modified"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 416 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/{id}/download [get]
+func (s *fileService) DownloadFile(c *fiber.Ctx) error {
+
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	file, err := s.repo.FindByID(ctx, c.Params("id"))
+	if err != nil || !canAccessFile(c, file) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "File not found",
+		})
+	}
+
+	return s.sendFile(c, file)
+}
+
+// sendFile mengirim isi file (atau thumbnail-nya lewat ?size=) yang aksesnya sudah dicek,
+// dipakai download biasa dan download lewat signed link
+func (s *fileService) sendFile(c *fiber.Ctx, file *models.File) error {
+
+	if fileQuarantined(c, file) {
+		return nil
+	}
+
+	// ?size= memilih thumbnail foto, tanpa parameter dikirim file utama
+	key := file.StorageKey
+	if size := c.Query("size"); size != "" {
+		if !utils.IsPhotoVariant(size) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"message": "Invalid size. Allowed: small, medium, large",
+			})
+		}
+		thumb := findThumbnail(file, size)
+		if thumb == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"message": "Thumbnail not available for this file",
+			})
+		}
+		key = thumb.StorageKey
+	}
+
+	backend, err := s.storage.Backend(file.StorageBackend)
+	if err != nil {
+		slog.ErrorContext(c.UserContext(), "Backend storage file tidak tersedia", "file_id", file.ID.Hex(), "err", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Storage backend not available",
+		})
+	}
+
+	// Isi file masih dibaca setelah handler selesai (streaming), jadi context baca tidak ikut
+	// dibatalkan bersama request, tetapi dibatasi UPLOAD_TIMEOUT_SECONDS dan dibatalkan saat
+	// stream ditutup
+	readCtx, cancelRead := context.WithTimeout(context.WithoutCancel(c.UserContext()), s.timeouts.Upload)
+	content, info, err := backend.Get(readCtx, key)
+	if err != nil {
+		cancelRead()
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"message": "File content not found in storage",
+			})
+		}
+		slog.ErrorContext(c.UserContext(), "Gagal membuka file dari storage", "file_id", file.ID.Hex(), "backend", backend.Name(), "err", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to open file",
+		})
+	}
+
+	content = cancelOnClose{content, cancelRead}
+
+	size := info.Size
+	modTime := info.ModTime.UTC().Truncate(time.Second)
+	etag := `"` + info.ETag + `"`
+
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderLastModified, modTime.Format(http.TimeFormat))
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+
+	if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+		content.Close()
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	// If-Range: kirim rentang hanya jika file belum berubah sejak client menyimpannya
+	rangeHeader := c.Get(fiber.HeaderRange)
+	if ifRange := c.Get(fiber.HeaderIfRange); ifRange != "" && ifRange != etag && ifRange != modTime.Format(http.TimeFormat) {
+		rangeHeader = ""
+	}
+
+	byteRange, err := utils.ParseRange(rangeHeader, size)
 	if err != nil {
+		content.Close()
+		c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", size))
+		return c.Status(fiber.StatusRequestedRangeNotSatisfiable).JSON(fiber.Map{
+			"success": false,
+			"message": "Requested range not satisfiable",
+		})
+	}
+
+	disposition := "attachment"
+	if c.QueryBool("inline", false) {
+		disposition = "inline"
+	}
+	c.Set(fiber.HeaderContentDisposition, contentDisposition(disposition, file.OriginalName))
+	c.Set(fiber.HeaderContentType, file.FileType)
+
+	if byteRange == nil {
+		return c.Status(fiber.StatusOK).SendStream(content, int(size))
+	}
+
+	if _, err := content.Seek(byteRange.Start, io.SeekStart); err != nil {
+		content.Close()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to read file",
+		})
+	}
+	c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", byteRange.Start, byteRange.End(), size))
+	return c.Status(fiber.StatusPartialContent).SendStream(readCloser{io.LimitReader(content, byteRange.Length), content}, int(byteRange.Length))
+}
+
+// @Summary Presigned URL file
+// @Description Membuat URL unduh langsung ke object storage yang berlaku sementara. Hanya untuk file di backend yang mendukung (s3); file lokal diunduh lewat /files/{id}/download
+// @Tags Files
+// @Produce json
+// @Param id path string true "File ID"
+// @Param expires query int false "Masa berlaku dalam detik (default 900, maks 604800)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 501 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/{id}/presign [get]
+func (s *fileService) PresignFile(c *fiber.Ctx) error {
+
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	file, err := s.repo.FindByID(ctx, c.Params("id"))
+	if err != nil || !canAccessFile(c, file) {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"success": false,
 			"message": "File not found",
 		})
 	}
 
+	if fileQuarantined(c, file) {
+		return nil
+	}
+
+	expiry := time.Duration(c.QueryInt("expires", int(defaultPresignExpiry/time.Second))) * time.Second
+	if expiry <= 0 {
+		expiry = defaultPresignExpiry
+	}
+	if expiry > maxPresignExpiry {
+		expiry = maxPresignExpiry
+	}
+
+	backend, err := s.storage.Backend(file.StorageBackend)
+	if err != nil {
+		slog.ErrorContext(c.UserContext(), "Backend storage file tidak tersedia", "file_id", file.ID.Hex(), "err", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Storage backend not available",
+		})
+	}
+
+	url, err := backend.Presign(ctx, file.StorageKey, expiry, file.OriginalName)
+	if errors.Is(err, storage.ErrPresignNotSupported) {
+		return c.Status(fiber.StatusNotImplemented).JSON(fiber.Map{
+			"success":      false,
+			"message":      "Storage backend does not support presigned URLs",
+			"download_url": "/files/" + file.ID.Hex() + "/download",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to create presigned URL",
+		})
+	}
+
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    s.toFileResponse(file),
+		"data": fiber.Map{
+			"url":        url,
+			"expires_at": time.Now().Add(expiry),
+		},
 	})
 }
 
 // @Summary Delete file
-// @Description Hapus file dari storage dan database
+// @Description Hapus file dari database (admin semua file, user hanya miliknya). Isi file di storage hanya dihapus jika tidak ada file lain dengan hash SHA-256 yang sama. Referensi foto profil alumni, lampiran pekerjaan dan versi dokumen ikut dilepas. Penghapusan oleh admin dicatat di audit log
 // @Tags Files
 // @Produce json
 // @Param id path string true "File ID"
@@ -279,40 +709,310 @@ func (s *fileService) GetFileByID(c *fiber.Ctx) error {
 // @Router /files/{id} [delete]
 func (s *fileService) DeleteFile(c *fiber.Ctx) error {
 
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
 	id := c.Params("id")
-	file, err := s.repo.FindByID(id)
-	if err != nil {
+	file, err := s.repo.FindByID(ctx, id)
+	if err != nil || !canAccessFile(c, file) {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"success": false,
 			"message": "File not found",
 		})
 	}
 
-	if err := os.Remove(file.FilePath); err != nil {
-		fmt.Println("Warning: failed to remove file from storage:", err)
+	// Lepas referensi dulu agar tidak ada foto profil / lampiran / versi dokumen yang menunjuk ke file yang sudah hilang
+	if err := s.clearFileReferences(ctx, file.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to clear file references",
+		})
 	}
 
-	if err := s.repo.Delete(id); err != nil {
+	if err := s.repo.Delete(ctx, id); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
 			"message": "Failed to delete file",
 		})
 	}
 
+	// Isi file hanya dihapus dari storage jika tidak ada file lain dengan hash yang sama
+	s.releaseFile(ctx, file)
+
+	if role, _ := c.Locals("role").(string); role == "admin" {
+		detail := map[string]interface{}{
+			"file_name":     file.FileName,
+			"original_name": file.OriginalName,
+			"file_type":     file.FileType,
+			"file_size":     file.FileSize,
+		}
+		if file.UserID != nil {
+			detail["owner_id"] = file.UserID.Hex()
+		}
+		recordAudit(c, s.auditRepo, s.timeouts.Write, models.AuditAksiHapusFile, "files", id, detail)
+	}
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "File deleted successfully",
 	})
 }
 
-func (s *fileService) toFileResponse(file *models.File) *models.FileResponse {
+func newFileResponse(file *models.File) *models.FileResponse {
+	var thumbnails []models.FileThumbnailResponse
+	for _, t := range file.Thumbnails {
+		thumbnails = append(thumbnails, models.FileThumbnailResponse{
+			Size:        t.Size,
+			Width:       t.Width,
+			Height:      t.Height,
+			FileSize:    t.FileSize,
+			DownloadURL: "/files/" + file.ID.Hex() + "/download?size=" + t.Size,
+		})
+	}
+
 	return &models.FileResponse{
 		ID:           file.ID.Hex(),
 		FileName:     file.FileName,
 		OriginalName: file.OriginalName,
-		FilePath:     file.FilePath,
 		FileSize:     file.FileSize,
 		FileType:     file.FileType,
+		SHA256:       file.SHA256,
+		Kategori:     file.Kategori,
+		Width:        file.Width,
+		Height:       file.Height,
+		DownloadURL:  "/files/" + file.ID.Hex() + "/download",
+		Thumbnails:   thumbnails,
+		ScanStatus:   file.ScanStatus,
 		UploadedAt:   file.UploadedAt,
 	}
 }
+
+// clearFileReferences melepas file dari foto profil alumni, lampiran pekerjaan dan riwayat dokumen
+func (s *fileService) clearFileReferences(ctx context.Context, fileID primitive.ObjectID) error {
+	if err := s.alumniRepo.ClearFotoFile(ctx, fileID); err != nil {
+		return err
+	}
+	if err := s.pekerjaanRepo.RemoveLampiranFile(ctx, fileID); err != nil {
+		return err
+	}
+	return s.dokumenRepo.RemoveFile(ctx, fileID)
+}
+
+// fileReferenced mengecek apakah file masih dirujuk foto profil alumni, lampiran pekerjaan
+// atau versi dokumen
+func (s *fileService) fileReferenced(ctx context.Context, fileID primitive.ObjectID) (bool, error) {
+	if used, err := s.alumniRepo.UsesFotoFile(ctx, fileID); err != nil || used {
+		return used, err
+	}
+	if used, err := s.pekerjaanRepo.UsesLampiranFile(ctx, fileID); err != nil || used {
+		return used, err
+	}
+	return s.dokumenRepo.UsesFile(ctx, fileID)
+}
+
+// removeFile menghapus file beserta referensinya tanpa response HTTP, dipakai
+// rekonsiliasi dan retensi versi dokumen
+func (s *fileService) removeFile(ctx context.Context, file *models.File) error {
+	if err := s.clearFileReferences(ctx, file.ID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, file.ID.Hex()); err != nil {
+		return err
+	}
+	s.releaseFile(ctx, file)
+	return nil
+}
+
+// releaseFile melepas isi file dari storage. File hasil dedup mengurangi ref_count blob-nya
+// dan objek baru dihapus jika tidak ada file lain yang memakainya; file lama (tanpa hash)
+// langsung dihapus. Kegagalan hanya dicatat di log.
+func (s *fileService) releaseFile(ctx context.Context, file *models.File) {
+	backend, err := s.storage.Backend(file.StorageBackend)
+	if err != nil {
+		slog.WarnContext(ctx, "Backend storage file tidak tersedia", "file_id", file.ID.Hex(), "err", err)
+		return
+	}
+	if file.SHA256 == "" {
+		s.deleteObjects(ctx, backend, file)
+		return
+	}
+
+	_, unused, err := s.blobRepo.Release(ctx, file.SHA256)
+	if err != nil {
+		slog.WarnContext(ctx, "Gagal melepas blob", "sha256", file.SHA256, "err", err)
+		return
+	}
+	if unused {
+		s.deleteObjects(ctx, backend, file)
+	}
+}
+
+// storeBlob mengambil referensi blob untuk isi fileModel (sudah di-hash) lalu menyimpan
+// isi dan thumbnail-nya ke storage jika blob belum punya objek. Key objek dan thumbnail
+// diambil dari blob yang tersimpan dan diisikan ke fileModel.
+func (s *fileService) storeBlob(ctx context.Context, fileModel *models.File, body io.ReadSeeker, thumbs map[string]*utils.ProcessedImage) (storage.Storage, error) {
+	ext := utils.ExtensionForContentType(fileModel.FileType)
+	blob := &models.FileBlob{
+		SHA256:         fileModel.SHA256,
+		StorageBackend: s.storage.Default().Name(),
+		StorageKey:     blobKey(fileModel.SHA256, primitive.NewObjectID().Hex(), ext),
+		Size:           fileModel.FileSize,
+		ContentType:    fileModel.FileType,
+	}
+	for _, t := range fileModel.Thumbnails {
+		blob.ThumbnailKeys = append(blob.ThumbnailKeys, thumbnailKey(blob.StorageKey, t.Size, ext))
+	}
+	blob, err := s.blobRepo.Acquire(ctx, blob)
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal mencatat blob", "sha256", fileModel.SHA256, "err", err)
+		return nil, newUploadError(fiber.StatusInternalServerError, "Failed to save file")
+	}
+	fileModel.StorageBackend = blob.StorageBackend
+	fileModel.StorageKey = blob.StorageKey
+	for i := range fileModel.Thumbnails {
+		fileModel.Thumbnails[i].StorageKey = thumbnailKey(blob.StorageKey, fileModel.Thumbnails[i].Size, ext)
+	}
+
+	backend, err := s.storage.Backend(blob.StorageBackend)
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal mengecek blob di storage", "sha256", fileModel.SHA256, "err", err)
+		s.releaseFile(ctx, fileModel)
+		return nil, newUploadError(fiber.StatusInternalServerError, "Storage backend not available")
+	}
+	if !s.blobMissing(ctx, backend, blob) {
+		return backend, nil
+	}
+
+	if _, err := backend.Put(ctx, fileModel.StorageKey, body, fileModel.FileSize, fileModel.FileType); err != nil {
+		slog.ErrorContext(ctx, "Gagal menyimpan file ke storage", "backend", backend.Name(), "err", err)
+		s.releaseFile(ctx, fileModel)
+		return nil, newUploadError(fiber.StatusInternalServerError, "Failed to save file")
+	}
+	for _, t := range fileModel.Thumbnails {
+		data := thumbs[t.Size].Data
+		if _, err := backend.Put(ctx, t.StorageKey, bytes.NewReader(data), int64(len(data)), fileModel.FileType); err != nil {
+			slog.ErrorContext(ctx, "Gagal menyimpan thumbnail ke storage", "size", t.Size, "backend", backend.Name(), "err", err)
+			s.releaseFile(ctx, fileModel)
+			return nil, newUploadError(fiber.StatusInternalServerError, "Failed to save file")
+		}
+	}
+	return backend, nil
+}
+
+// blobMissing mengecek apakah isi blob harus disimpan: blob baru (referensi pertama)
+// atau blob lama yang objeknya ternyata tidak ada di storage
+func (s *fileService) blobMissing(ctx context.Context, backend storage.Storage, blob *models.FileBlob) bool {
+	if blob.RefCount <= 1 {
+		return true
+	}
+	_, err := backend.Stat(ctx, blob.StorageKey)
+	return errors.Is(err, storage.ErrNotFound)
+}
+
+// blobKey adalah key objek di storage untuk hash isi file, dikelompokkan per dua
+// karakter pertama hash agar satu folder tidak berisi terlalu banyak file. Setiap catatan
+// blob baru mendapat generation sendiri, sehingga objek blob lama yang sedang dihapus
+// releaseFile tidak pernah menimpa atau terhapus bersama upload baru dengan hash yang sama.
+func blobKey(hash, generation, ext string) string {
+	return "blobs/" + hash[:2] + "/" + hash + "-" + generation + ext
+}
+
+// thumbnailKey adalah key thumbnail ukuran size untuk objek blob dengan key tersebut
+func thumbnailKey(key, size, ext string) string {
+	return strings.TrimSuffix(key, ext) + "_" + size + ext
+}
+
+// deleteObjects menghapus isi file beserta thumbnail-nya dari storage. Kegagalan hanya
+// dicatat di log karena metadata tetap harus bisa dihapus.
+func (s *fileService) deleteObjects(ctx context.Context, backend storage.Storage, file *models.File) {
+	keys := []string{file.StorageKey}
+	for _, t := range file.Thumbnails {
+		keys = append(keys, t.StorageKey)
+	}
+	for _, key := range keys {
+		if err := backend.Delete(ctx, key); err != nil {
+			slog.WarnContext(ctx, "Gagal menghapus objek dari storage", "key", key, "backend", backend.Name(), "err", err)
+		}
+	}
+}
+
+// loadFileResponses mengambil metadata file untuk disisipkan di response data lain (?embed=file).
+// File yang sudah tidak ada dilewati.
+func loadFileResponses(ctx context.Context, fileRepo repository.FileRepository, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.FileResponse, error) {
+	files, err := fileRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[primitive.ObjectID]*models.FileResponse, len(files))
+	for i := range files {
+		result[files[i].ID] = newFileResponse(&files[i])
+	}
+	return result, nil
+}
+
+// canAccessFile memakai aturan yang sama dengan daftar file:
+// admin melihat semua file, user lain hanya file miliknya
+func canAccessFile(c *fiber.Ctx, file *models.File) bool {
+	role, _ := c.Locals("role").(string)
+	if role == "admin" {
+		return true
+	}
+	userID, _ := c.Locals("user_id").(string)
+	return file.UserID != nil && file.UserID.Hex() == userID
+}
+
+// findThumbnail mencari thumbnail file dengan ukuran tertentu
+func findThumbnail(file *models.File, size string) *models.FileThumbnail {
+	for i := range file.Thumbnails {
+		if file.Thumbnails[i].Size == size {
+			return &file.Thumbnails[i]
+		}
+	}
+	return nil
+}
+
+// contentDisposition membuat header Content-Disposition dengan nama asli file.
+// Nama non-ASCII dikirim sebagai filename* (RFC 5987) plus filename ASCII untuk client lama.
+func contentDisposition(disposition, name string) string {
+	name = filepath.Base(name)
+	ascii := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, name)
+	header := mime.FormatMediaType(disposition, map[string]string{"filename": name})
+	if ascii == name {
+		return header
+	}
+	return fmt.Sprintf(`%s; filename="%s"`, header, ascii)
+}
+
+// etagMatches mengecek header If-None-Match (daftar ETag dipisah koma atau "*")
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// readCloser menutup file setelah potongan (Range) selesai dikirim
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// cancelOnClose membatalkan context baca storage begitu stream isi file ditutup
+type cancelOnClose struct {
+	io.ReadSeekCloser
+	cancel context.CancelFunc
+}
+
+func (r cancelOnClose) Close() error {
+	err := r.ReadSeekCloser.Close()
+	r.cancel()
+	return err
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	models "crud-app/app/model"
+	"crud-app/storage"
+	"crud-app/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeBlobRepo meniru FileBlobRepository (upsert $inc dan delete bersyarat ref_count) di
+// memori. afterDelete dipanggil setelah Release menghapus catatan blob dan sebelum
+// pemanggil menghapus isinya dari storage.
+type fakeBlobRepo struct {
+	mu          sync.Mutex
+	blobs       map[string]*models.FileBlob
+	afterDelete func()
+}
+
+func (r *fakeBlobRepo) Acquire(ctx context.Context, blob *models.FileBlob) (*models.FileBlob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.blobs[blob.SHA256]
+	if !ok {
+		copied := *blob
+		copied.RefCount = 0
+		stored = &copied
+		r.blobs[blob.SHA256] = stored
+	}
+	stored.RefCount++
+	result := *stored
+	return &result, nil
+}
+
+func (r *fakeBlobRepo) Release(ctx context.Context, sha256 string) (*models.FileBlob, bool, error) {
+	r.mu.Lock()
+	stored, ok := r.blobs[sha256]
+	if !ok || stored.RefCount <= 0 {
+		r.mu.Unlock()
+		return nil, false, nil
+	}
+	stored.RefCount--
+	blob := *stored
+	if blob.RefCount > 0 {
+		r.mu.Unlock()
+		return &blob, false, nil
+	}
+	delete(r.blobs, sha256)
+	r.mu.Unlock()
+
+	if r.afterDelete != nil {
+		r.afterDelete()
+	}
+	return &blob, true, nil
+}
+
+func newDedupFile(hash string) *models.File {
+	return &models.File{
+		ID:       primitive.NewObjectID(),
+		SHA256:   hash,
+		FileSize: 4,
+		FileType: "image/png",
+		Thumbnails: []models.FileThumbnail{
+			{Size: "small", FileSize: 2},
+		},
+	}
+}
+
+// Upload baru dengan hash yang sama di antara penghapusan catatan blob dan penghapusan
+// objeknya tidak boleh kehilangan isinya
+func TestReleaseFileDoesNotDeleteConcurrentUpload(t *testing.T) {
+	const hash = "cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34"
+	store := newTestStorage(t)
+	backend := store.Default()
+	blobs := &fakeBlobRepo{blobs: map[string]*models.FileBlob{}}
+	s := &fileService{blobRepo: blobs, storage: store}
+	ctx := context.Background()
+	thumbs := map[string]*utils.ProcessedImage{"small": {Data: []byte("th")}}
+
+	old := newDedupFile(hash)
+	if _, err := s.storeBlob(ctx, old, bytes.NewReader([]byte("data")), thumbs); err != nil {
+		t.Fatalf("storeBlob file lama: %v", err)
+	}
+
+	// Upload kedua masuk tepat setelah catatan blob file lama dihapus
+	uploaded := newDedupFile(hash)
+	blobs.afterDelete = func() {
+		blobs.afterDelete = nil
+		if _, err := s.storeBlob(ctx, uploaded, bytes.NewReader([]byte("data")), thumbs); err != nil {
+			t.Errorf("storeBlob upload baru: %v", err)
+		}
+	}
+	s.releaseFile(ctx, old)
+
+	if uploaded.StorageKey == old.StorageKey {
+		t.Fatalf("upload baru memakai key objek yang sedang dihapus: %s", uploaded.StorageKey)
+	}
+	keys := []string{uploaded.StorageKey, uploaded.Thumbnails[0].StorageKey}
+	for _, key := range keys {
+		if _, err := backend.Stat(ctx, key); err != nil {
+			t.Errorf("objek %s upload baru hilang: %v", key, err)
+		}
+	}
+	for _, key := range []string{old.StorageKey, old.Thumbnails[0].StorageKey} {
+		if _, err := backend.Stat(ctx, key); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("objek %s file lama seharusnya dihapus, err = %v", key, err)
+		}
+	}
+	if blob := blobs.blobs[hash]; blob == nil || blob.RefCount != 1 || blob.StorageKey != uploaded.StorageKey {
+		t.Errorf("catatan blob setelah upload baru = %+v", blob)
+	}
+}
+
+func TestStoreBlobReusesExistingObject(t *testing.T) {
+	const hash = "ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56"
+	store := newTestStorage(t)
+	blobs := &fakeBlobRepo{blobs: map[string]*models.FileBlob{}}
+	s := &fileService{blobRepo: blobs, storage: store}
+	ctx := context.Background()
+	thumbs := map[string]*utils.ProcessedImage{"small": {Data: []byte("th")}}
+
+	first, second := newDedupFile(hash), newDedupFile(hash)
+	for _, f := range []*models.File{first, second} {
+		if _, err := s.storeBlob(ctx, f, bytes.NewReader([]byte("data")), thumbs); err != nil {
+			t.Fatalf("storeBlob: %v", err)
+		}
+	}
+
+	if second.StorageKey != first.StorageKey || second.Thumbnails[0].StorageKey != first.Thumbnails[0].StorageKey {
+		t.Errorf("file dengan hash sama seharusnya memakai objek yang sama: %s / %s", first.StorageKey, second.StorageKey)
+	}
+	if got := blobs.blobs[hash].RefCount; got != 2 {
+		t.Errorf("ref_count = %d, seharusnya 2", got)
+	}
+}
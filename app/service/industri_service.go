@@ -0,0 +1,183 @@
+package service
+
+import (
+	"log/slog"
+
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/config"
+	"crud-app/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Batas jumlah hasil autocomplete industri
+const (
+	defaultLimitCariIndustri = 10
+	maxLimitCariIndustri     = 50
+)
+
+type IndustriService struct {
+	repo     repository.IndustriRepository
+	timeouts config.Timeouts
+}
+
+func NewIndustriService(r repository.IndustriRepository, timeouts config.Timeouts) *IndustriService {
+	return &IndustriService{repo: r, timeouts: timeouts}
+}
+
+// GetAll godoc
+// @Summary Daftar taksonomi industri (KBLI)
+// @Description Mengambil turunan langsung dari kode induk; tanpa induk mengembalikan level kategori (A-U)
+// @Tags Industri
+// @Produce json
+// @Param induk query string false "Kode induk (mis. J)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/industri [get]
+func (s *IndustriService) GetAll(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	list, err := s.repo.GetChildren(ctx, c.Query("induk", ""))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data industri"})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": list})
+}
+
+// Search godoc
+// @Summary Cari industri (autocomplete)
+// @Description Mencari kode KBLI berdasarkan awalan kode, judul atau kata kunci
+// @Tags Industri
+// @Produce json
+// @Param q query string true "Kata kunci atau awalan kode"
+// @Param limit query int false "Jumlah hasil (default 10, maks 50)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/industri/search [get]
+func (s *IndustriService) Search(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	q := c.Query("q", "")
+	if q == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Parameter q wajib diisi"})
+	}
+
+	limit := c.QueryInt("limit", defaultLimitCariIndustri)
+	if limit < 1 {
+		limit = defaultLimitCariIndustri
+	}
+	if limit > maxLimitCariIndustri {
+		limit = maxLimitCariIndustri
+	}
+
+	list, err := s.repo.Search(ctx, q, int64(limit))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mencari data industri"})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": list})
+}
+
+// GetByKode godoc
+// @Summary Detail kode industri
+// @Description Mengambil satu kode KBLI beserta leluhur dan turunan langsungnya
+// @Tags Industri
+// @Produce json
+// @Param kode path string true "Kode KBLI"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/industri/{kode} [get]
+func (s *IndustriService) GetByKode(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	industri, err := s.repo.GetByKode(ctx, c.Params("kode"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data industri"})
+	}
+	if industri == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Kode industri tidak ditemukan"})
+	}
+
+	leluhur, err := s.repo.GetByKodes(ctx, industri.Path)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil leluhur industri"})
+	}
+	turunan, err := s.repo.GetChildren(ctx, industri.Kode)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil turunan industri"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    models.DetailIndustri{Industri: *industri, Leluhur: leluhur, Turunan: turunan},
+	})
+}
+
+// Map godoc
+// @Summary Petakan bidang_industri lama ke kode KBLI
+// @Description Mencocokkan bidang_industri teks bebas yang belum punya kode_industri dengan judul / kata kunci taksonomi. Default dry_run=true hanya menampilkan hasil (admin only)
+// @Tags Industri
+// @Produce json
+// @Param dry_run query bool false "Hanya tampilkan hasil tanpa menyimpan (default true)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/industri/map [post]
+func (s *IndustriService) Map(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Batch)
+	defer cancel()
+
+	dryRun := c.QueryBool("dry_run", true)
+	slog.InfoContext(ctx, "Admin menjalankan pemetaan bidang industri", "dry_run", dryRun)
+
+	taksonomi, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data industri"})
+	}
+
+	names, err := s.repo.GetUnmappedBidang(ctx)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil bidang industri pada pekerjaan"})
+	}
+
+	mapped := []models.HasilMapIndustri{}
+	unmapped := []models.HasilMapIndustri{}
+	var updated int64
+
+	for _, n := range names {
+		hasil := models.HasilMapIndustri{Nama: n.Nama, JumlahPekerjaan: n.JumlahPekerjaan}
+
+		industri := utils.MatchIndustri(n.Nama, taksonomi)
+		if industri == nil {
+			unmapped = append(unmapped, hasil)
+			continue
+		}
+		hasil.KodeIndustri = industri.Kode
+		hasil.JudulIndustri = industri.Judul
+		mapped = append(mapped, hasil)
+
+		if !dryRun {
+			count, err := s.repo.SetKodeIndustri(ctx, []string{n.Nama}, industri.Kode)
+			if err != nil {
+				return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan kode industri pekerjaan"})
+			}
+			updated += count
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success":           true,
+		"dry_run":           dryRun,
+		"mapped":            mapped,
+		"unmapped":          unmapped,
+		"updated_pekerjaan": updated,
+	})
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"log/slog"
+	"time"
+
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/config"
+	"crud-app/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type KarirService struct {
+	alumniRepo    repository.AlumniRepository
+	pekerjaanRepo repository.PekerjaanRepository
+	timeouts      config.Timeouts
+}
+
+func NewKarirService(alumniRepo repository.AlumniRepository, pekerjaanRepo repository.PekerjaanRepository, timeouts config.Timeouts) *KarirService {
+	return &KarirService{alumniRepo: alumniRepo, pekerjaanRepo: pekerjaanRepo, timeouts: timeouts}
+}
+
+// GetCareer godoc
+// @Summary Timeline karir alumni
+// @Description Mengambil pekerjaan alumni secara kronologis beserta posisi saat ini, masa kerja, total pengalaman, jeda antar pekerjaan dan masa tunggu dari kelulusan ke pekerjaan pertama. Hanya untuk admin atau alumni yang bersangkutan
+// @Tags Alumni
+// @Produce json
+// @Param id path string true "ID Alumni (MongoDB ObjectID)"
+// @Success 200 {object} models.KarirAlumni
+// @Failure 403 {object} map[string]interface{} "bukan admin atau pemilik data"
+// @Failure 404 {object} map[string]interface{} "alumni tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "error response"
+// @Security Bearer
+// @Router /unair/alumni/{id}/career [get]
+func (s *KarirService) GetCareer(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	id := c.Params("id")
+	role, _ := c.Locals("role").(string)
+	alumniID, _ := c.Locals("alumni_id").(string)
+	slog.DebugContext(ctx, "Akses timeline karir alumni", "alumni_id", id)
+
+	// Hanya admin atau alumni itu sendiri
+	if role != "admin" && alumniID != id {
+		return c.Status(403).JSON(fiber.Map{"error": "Kamu tidak bisa melihat karir alumni lain"})
+	}
+
+	alumni, err := s.alumniRepo.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data alumni"})
+	}
+	if alumni == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Alumni tidak ditemukan"})
+	}
+
+	list, err := s.pekerjaanRepo.GetByAlumniID(ctx, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data pekerjaan alumni"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    buildKarirAlumni(alumni, list, utils.Today()),
+	})
+}
+
+// buildKarirAlumni menyusun timeline karir dari daftar pekerjaan yang sudah
+// terurut berdasarkan tanggal_mulai_kerja. Pekerjaan tanpa tanggal selesai
+// dihitung sampai today.
+func buildKarirAlumni(alumni *models.Alumni, list []models.Pekerjaan, today time.Time) models.KarirAlumni {
+	karir := models.KarirAlumni{
+		AlumniID:   alumni.ID,
+		Nama:       alumni.Nama,
+		TahunLulus: alumni.TahunLulus,
+		Pekerjaan:  []models.KarirPekerjaan{},
+		Jeda:       []models.JedaKarir{},
+	}
+	if len(list) == 0 {
+		return karir
+	}
+
+	type interval struct{ start, end time.Time }
+	var merged []interval
+
+	for _, p := range list {
+		end := today
+		if p.TanggalSelesaiKerja != nil {
+			end = *p.TanggalSelesaiKerja
+		}
+
+		item := models.KarirPekerjaan{
+			ID:                  p.ID,
+			NamaPerusahaan:      p.NamaPerusahaan,
+			PosisiJabatan:       p.PosisiJabatan,
+			BidangIndustri:      p.BidangIndustri,
+			LokasiKerja:         p.LokasiKerja,
+			TanggalMulaiKerja:   p.TanggalMulaiKerja,
+			TanggalSelesaiKerja: p.TanggalSelesaiKerja,
+			StatusPekerjaan:     p.StatusPekerjaan,
+			MasaKerja:           durasi(p.TanggalMulaiKerja, end),
+		}
+		karir.Pekerjaan = append(karir.Pekerjaan, item)
+
+		// Posisi saat ini: pekerjaan belum selesai dengan tanggal mulai terbaru
+		if p.StatusPekerjaan != models.StatusPekerjaanSelesai && p.TanggalSelesaiKerja == nil {
+			current := item
+			karir.PosisiSaatIni = &current
+		}
+
+		// Gabungkan periode yang overlap agar pengalaman tidak dihitung ganda
+		if n := len(merged); n > 0 && !p.TanggalMulaiKerja.After(merged[n-1].end.AddDate(0, 0, 1)) {
+			if end.After(merged[n-1].end) {
+				merged[n-1].end = end
+			}
+			continue
+		}
+		merged = append(merged, interval{start: p.TanggalMulaiKerja, end: end})
+	}
+
+	for i, m := range merged {
+		d := durasi(m.start, m.end)
+		karir.TotalPengalaman.Bulan += d.Bulan
+		karir.TotalPengalaman.Hari += d.Hari
+
+		if i > 0 {
+			prevEnd := merged[i-1].end
+			karir.Jeda = append(karir.Jeda, models.JedaKarir{
+				Dari:   prevEnd,
+				Sampai: m.start,
+				Durasi: durasi(prevEnd, m.start),
+			})
+		}
+	}
+
+	first := list[0].TanggalMulaiKerja
+	lulus := utils.GraduationDate(alumni.TahunLulus)
+	if alumni.TahunLulus > 0 {
+		if first.Before(lulus) {
+			karir.BekerjaSebelumLulus = true
+			karir.MasaTungguPekerjaanPertama = &models.Durasi{}
+		} else {
+			tunggu := durasi(lulus, first)
+			karir.MasaTungguPekerjaanPertama = &tunggu
+		}
+	}
+
+	return karir
+}
+
+func durasi(start, end time.Time) models.Durasi {
+	if end.Before(start) {
+		return models.Durasi{}
+	}
+	return models.Durasi{
+		Bulan: utils.MonthsBetween(start, end),
+		Hari:  utils.DaysBetween(start, end),
+	}
+}
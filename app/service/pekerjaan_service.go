@@ -3,21 +3,32 @@ package service
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
 	models "crud-app/app/model"
 	"crud-app/app/repository"
+	"crud-app/config"
+	"crud-app/metrics"
+	"crud-app/utils"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type PekerjaanService struct {
-	repo repository.PekerjaanRepository
+	repo           repository.PekerjaanRepository
+	perusahaanRepo repository.PerusahaanRepository
+	industriRepo   repository.IndustriRepository
+	fileRepo       repository.FileRepository
+	timeouts       config.Timeouts
 }
 
-func NewPekerjaanService(r repository.PekerjaanRepository) *PekerjaanService {
-	return &PekerjaanService{repo: r}
+func NewPekerjaanService(r repository.PekerjaanRepository, perusahaanRepo repository.PerusahaanRepository, industriRepo repository.IndustriRepository, fileRepo repository.FileRepository, timeouts config.Timeouts) *PekerjaanService {
+	return &PekerjaanService{repo: r, perusahaanRepo: perusahaanRepo, industriRepo: industriRepo, fileRepo: fileRepo, timeouts: timeouts}
 }
 
 // @Summary Get all pekerjaan
@@ -30,9 +41,9 @@ func NewPekerjaanService(r repository.PekerjaanRepository) *PekerjaanService {
 // @Security Bearer
 // @Router /unair/pekerjaan-alumni [get]
 func (s *PekerjaanService) GetAll(c *fiber.Ctx) error {
-	ctx := context.Background()
-	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/pekerjaan", username)
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+	slog.DebugContext(ctx, "Akses daftar pekerjaan")
 
 	data, err := s.repo.GetAll(ctx)
 	if err != nil {
@@ -45,18 +56,24 @@ func (s *PekerjaanService) GetAll(c *fiber.Ctx) error {
 }
 
 // @Summary Get pekerjaan by ID
-// @Description Get detail data pekerjaan berdasarkan ID
+// @Description Get detail data pekerjaan berdasarkan ID. Dengan embed=file, metadata file lampiran ikut disertakan
 // @Tags Pekerjaan_Alumni
 // @Produce json
 // @Param id path string true "Pekerjaan ID"
+// @Param embed query string false "Isi file untuk menyertakan metadata file lampiran"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Security Bearer
 // @Router /unair/pekerjaan-alumni/{id} [get]
 func (s *PekerjaanService) GetByID(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
 	id := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "ID pekerjaan tidak valid"})
+	}
 
 	data, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -65,10 +82,172 @@ func (s *PekerjaanService) GetByID(c *fiber.Ctx) error {
 		}
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
+	if data == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Pekerjaan tidak ditemukan"})
+	}
+
+	if c.Query("embed") == "file" {
+		if err := s.embedLampiran(ctx, data); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data lampiran"})
+		}
+	}
 
 	return c.JSON(fiber.Map{"success": true, "data": data})
 }
 
+// @Summary Tambah lampiran pekerjaan
+// @Description Menghubungkan file yang sudah di-upload (mis. surat keterangan kerja, sertifikat) ke data pekerjaan. Admin untuk semua pekerjaan, alumni hanya untuk pekerjaannya sendiri dengan file miliknya
+// @Tags Pekerjaan_Alumni
+// @Accept json
+// @Produce json
+// @Param id path string true "Pekerjaan ID"
+// @Param body body models.TambahLampiranRequest true "File dan kategori lampiran"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/pekerjaan-alumni/{id}/lampiran [post]
+func (s *PekerjaanService) AddLampiran(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	var req models.TambahLampiranRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Request body tidak valid"})
+	}
+	if req.FileID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "file_id wajib diisi"})
+	}
+	if !models.IsKategoriLampiranValid(req.Kategori) {
+		return c.Status(400).JSON(fiber.Map{"error": "kategori harus salah satu dari surat_keterangan_kerja, kontrak_kerja, sertifikat, lainnya"})
+	}
+
+	data, status, msg := s.getManagedPekerjaan(ctx, c)
+	if data == nil {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	file, err := s.fileRepo.FindByID(ctx, req.FileID)
+	if err != nil || !canAccessFile(c, file) {
+		return c.Status(404).JSON(fiber.Map{"error": "File tidak ditemukan"})
+	}
+	if file.Quarantined() {
+		return c.Status(409).JSON(fiber.Map{"error": "File sedang dikarantina (belum lolos pemindaian malware atau isinya hilang)"})
+	}
+
+	lampiran := models.LampiranPekerjaan{
+		FileID:     file.ID,
+		Kategori:   req.Kategori,
+		Keterangan: strings.TrimSpace(req.Keterangan),
+	}
+	added, err := s.repo.AddLampiran(ctx, data.ID, lampiran)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menambah lampiran"})
+	}
+	if !added {
+		return c.Status(409).JSON(fiber.Map{"error": "File sudah menjadi lampiran pekerjaan ini"})
+	}
+
+	updated, err := s.repo.GetByID(ctx, data.ID.Hex())
+	if err != nil || updated == nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data pekerjaan"})
+	}
+	if err := s.embedLampiran(ctx, updated); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data lampiran"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"data":    updated,
+		"message": "Lampiran berhasil ditambahkan",
+	})
+}
+
+// @Summary Hapus lampiran pekerjaan
+// @Description Melepas file dari lampiran pekerjaan. File-nya tidak dihapus (gunakan DELETE /files/{id})
+// @Tags Pekerjaan_Alumni
+// @Produce json
+// @Param id path string true "Pekerjaan ID"
+// @Param file_id path string true "File ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/pekerjaan-alumni/{id}/lampiran/{file_id} [delete]
+func (s *PekerjaanService) RemoveLampiran(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	data, status, msg := s.getManagedPekerjaan(ctx, c)
+	if data == nil {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	fileID, err := primitive.ObjectIDFromHex(c.Params("file_id"))
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Lampiran tidak ditemukan"})
+	}
+
+	removed, err := s.repo.RemoveLampiran(ctx, data.ID, fileID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghapus lampiran"})
+	}
+	if !removed {
+		return c.Status(404).JSON(fiber.Map{"error": "Lampiran tidak ditemukan"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Lampiran berhasil dilepas",
+	})
+}
+
+// getManagedPekerjaan mengambil pekerjaan dari parameter :id dan memastikan user boleh
+// mengubahnya (admin semua, alumni hanya miliknya). Jika gagal, status dan pesan error dikembalikan.
+func (s *PekerjaanService) getManagedPekerjaan(ctx context.Context, c *fiber.Ctx) (*models.Pekerjaan, int, string) {
+	id := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, 404, "Pekerjaan tidak ditemukan"
+	}
+	data, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, 500, "Gagal mengambil data pekerjaan"
+	}
+	if data == nil {
+		return nil, 404, "Pekerjaan tidak ditemukan"
+	}
+
+	role, _ := c.Locals("role").(string)
+	alumniID, _ := c.Locals("alumni_id").(string)
+	if role != "admin" && data.AlumniID.Hex() != alumniID {
+		return nil, 403, "Kamu tidak bisa mengubah lampiran pekerjaan milik orang lain"
+	}
+	return data, 0, ""
+}
+
+// embedLampiran mengisi metadata file setiap lampiran pekerjaan
+func (s *PekerjaanService) embedLampiran(ctx context.Context, data *models.Pekerjaan) error {
+	if len(data.Lampiran) == 0 {
+		return nil
+	}
+	ids := make([]primitive.ObjectID, 0, len(data.Lampiran))
+	for _, l := range data.Lampiran {
+		ids = append(ids, l.FileID)
+	}
+	files, err := loadFileResponses(ctx, s.fileRepo, ids)
+	if err != nil {
+		return err
+	}
+	for i := range data.Lampiran {
+		data.Lampiran[i].File = files[data.Lampiran[i].FileID]
+	}
+	return nil
+}
+
 // @Summary Get pekerjaan by alumni ID
 // @Description Get data pekerjaan berdasarkan alumni ID (admin only)
 // @Tags Pekerjaan_Alumni
@@ -80,7 +259,8 @@ func (s *PekerjaanService) GetByID(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/pekerjaan-alumni/alumni/{alumni_id} [get]
 func (s *PekerjaanService) GetByAlumniID(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
 	alumniID := c.Params("alumni_id")
 
 	data, err := s.repo.GetByAlumniID(ctx, alumniID)
@@ -94,7 +274,7 @@ func (s *PekerjaanService) GetByAlumniID(c *fiber.Ctx) error {
 }
 
 // @Summary Create new pekerjaan
-// @Description Tambah data pekerjaan alumni baru (admin only)
+// @Description Tambah data pekerjaan alumni baru (admin only). status_pekerjaan: aktif, cuti, selesai
 // @Accept json
 // @Tags Pekerjaan_Alumni
 // @Produce json
@@ -105,9 +285,9 @@ func (s *PekerjaanService) GetByAlumniID(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/pekerjaan-alumni [post]
 func (s *PekerjaanService) Create(c *fiber.Ctx) error {
-	ctx := context.Background()
-	username := c.Locals("username").(string)
-	log.Printf("Admin %s menambahkan data pekerjaan_alumni baru", username)
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+	slog.InfoContext(ctx, "Admin menambahkan data pekerjaan alumni")
 
 	// Gunakan struct CreatePekerjaanRequest (bukan Pekerjaan langsung)
 	var req models.CreatePekerjaanRequest
@@ -118,13 +298,13 @@ func (s *PekerjaanService) Create(c *fiber.Ctx) error {
 		})
 	}
 
-	// Validasi field wajib
-	if req.AlumniID == "" || req.NamaPerusahaan == "" || req.PosisiJabatan == "" ||
-		req.BidangIndustri == "" || req.LokasiKerja == "" || req.GajiRange == "" ||
-		req.TanggalMulaiKerja == "" || req.StatusPekerjaan == "" || req.DeskripsiPekerjaan == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Semua field wajib diisi dan tidak boleh kosong",
-		})
+	if req.AlumniID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Semua field wajib diisi dan tidak boleh kosong"})
+	}
+
+	// Validasi dan lengkapi perusahaan, industri, gaji, status dan tanggal
+	if err := s.preparePekerjaanInput(ctx, nil, &req.PekerjaanInput); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	// Panggil repository untuk simpan ke MongoDB
@@ -144,7 +324,7 @@ func (s *PekerjaanService) Create(c *fiber.Ctx) error {
 }
 
 // @Summary Update pekerjaan
-// @Description Update data pekerjaan (admin only)
+// @Description Update data pekerjaan (admin only). Tanggal kosong berarti tidak diubah; status mengikuti transisi aktif -> cuti/selesai, cuti -> aktif/selesai
 // @Tags Pekerjaan_Alumni
 // @Accept json
 // @Produce json
@@ -157,7 +337,8 @@ func (s *PekerjaanService) Create(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/pekerjaan-alumni/{id} [put]
 func (s *PekerjaanService) Update(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
 	id := c.Params("id")
 
 	var req models.UpdatePekerjaanRequest
@@ -165,10 +346,20 @@ func (s *PekerjaanService) Update(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Body request tidak valid"})
 	}
 
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" ||
-		req.BidangIndustri == "" || req.LokasiKerja == "" || req.GajiRange == "" ||
-		req.StatusPekerjaan == "" || req.DeskripsiPekerjaan == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "Semua field wajib diisi"})
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "ID pekerjaan tidak valid"})
+	}
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+	if existing == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Pekerjaan tidak ditemukan"})
+	}
+
+	// Validasi perusahaan, industri, gaji serta transisi status dan tanggal pekerjaan
+	if err := s.preparePekerjaanInput(ctx, existing, &req.PekerjaanInput); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	updated, err := s.repo.Update(ctx, id, &req)
@@ -193,7 +384,8 @@ func (s *PekerjaanService) Update(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/pekerjaan-alumni/{id} [delete]
 func (s *PekerjaanService) SoftDelete(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
 	id := c.Params("id")
 	role := c.Locals("role").(string)
 	alumniID, _ := c.Locals("alumni_id").(string)
@@ -202,6 +394,7 @@ func (s *PekerjaanService) SoftDelete(c *fiber.Ctx) error {
 		if err := s.repo.SoftDeleteByID(ctx, id); err != nil {
 			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 		}
+		metrics.MovedToTrash("pekerjaan")
 		return c.JSON(fiber.Map{"success": true, "message": "Data pekerjaan berhasil dihapus oleh admin"})
 	}
 
@@ -216,6 +409,7 @@ func (s *PekerjaanService) SoftDelete(c *fiber.Ctx) error {
 	if err := s.repo.SoftDeleteByOwner(ctx, id, alumniID); err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
+	metrics.MovedToTrash("pekerjaan")
 
 	return c.JSON(fiber.Map{
 		"success": true,
@@ -234,7 +428,8 @@ func (s *PekerjaanService) SoftDelete(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/pekerjaan-alumni/{id} [patch]
 func (s *PekerjaanService) Restore(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
 	id := c.Params("id")
 	role := c.Locals("role").(string)
 	alumniID, _ := c.Locals("alumni_id").(string)
@@ -260,7 +455,8 @@ func (s *PekerjaanService) Restore(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/pekerjaan-alumni/trash [get]
 func (s *PekerjaanService) GetTrash(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
 	role := c.Locals("role").(string)
 
 	if role == "admin" {
@@ -289,7 +485,8 @@ func (s *PekerjaanService) GetTrash(c *fiber.Ctx) error {
 // @Security Bearer
 // @Router /unair/pekerjaan-alumni/trash/delete/{id} [delete]
 func (s *PekerjaanService) Delete(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
 	id := c.Params("id")
 	role := c.Locals("role").(string)
 
@@ -317,12 +514,18 @@ func (s *PekerjaanService) Delete(c *fiber.Ctx) error {
 // @Param search query string false "Search keyword"
 // @Param sortBy query string false "Sort by field" default(created_at)
 // @Param order query string false "Sort order" default(asc)
+// @Param status_pekerjaan query string false "Filter status: aktif, cuti, selesai"
+// @Param gaji_min query int false "Batas bawah gaji"
+// @Param gaji_max query int false "Batas atas gaji"
+// @Param gaji_periode query string false "Periode gaji filter: bulanan atau tahunan" default(bulanan)
+// @Param gaji_mata_uang query string false "Mata uang gaji filter" default(IDR)
 // @Success 200 {object} models.PekerjaanResponse
 // @Failure 500 {object} map[string]interface{}
 // @Security Bearer
 // @Router /unair/pekerjaan-alumni [get]
 func (s *PekerjaanService) GetPekerjaanService(c *fiber.Ctx) error {
-	ctx := context.Background()
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
 
 	page := c.QueryInt("page", 1)
 	limit := c.QueryInt("limit", 10)
@@ -332,12 +535,17 @@ func (s *PekerjaanService) GetPekerjaanService(c *fiber.Ctx) error {
 
 	offset := int64((page - 1) * limit)
 
-	data, err := s.repo.GetPekerjaanRepo(ctx, search, sortBy, order, int64(limit), offset)
+	filter, err := parsePekerjaanFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	data, err := s.repo.GetPekerjaanRepo(ctx, filter, sortBy, order, int64(limit), offset)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	total, err := s.repo.CountPekerjaanRepo(ctx, search)
+	total, err := s.repo.CountPekerjaanRepo(ctx, filter)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -356,3 +564,240 @@ func (s *PekerjaanService) GetPekerjaanService(c *fiber.Ctx) error {
 	}
 	return c.JSON(response)
 }
+
+// normalizeStatusPekerjaan menyeragamkan penulisan status_pekerjaan
+func normalizeStatusPekerjaan(status string) string {
+	return strings.ToLower(strings.TrimSpace(status))
+}
+
+// validatePekerjaanLifecycle memvalidasi status_pekerjaan beserta tanggal kerjanya.
+// existing bernilai nil untuk data baru. Nilai yang dikembalikan adalah
+// tanggal_selesai_kerja yang harus disimpan (YYYY-MM-DD, kosong = tidak diubah);
+// transisi ke "selesai" tanpa tanggal selesai otomatis diisi tanggal hari ini.
+func validatePekerjaanLifecycle(existing *models.Pekerjaan, status, tglMulaiStr, tglSelesaiStr string) (string, error) {
+	status = normalizeStatusPekerjaan(status)
+	if !models.IsValidStatusPekerjaan(status) {
+		return "", fmt.Errorf("status_pekerjaan harus salah satu dari: %s, %s, %s",
+			models.StatusPekerjaanAktif, models.StatusPekerjaanCuti, models.StatusPekerjaanSelesai)
+	}
+
+	if existing != nil && !models.CanTransitionStatusPekerjaan(existing.StatusPekerjaan, status) {
+		return "", fmt.Errorf("perubahan status dari '%s' ke '%s' tidak diizinkan", existing.StatusPekerjaan, status)
+	}
+
+	var tglMulai time.Time
+	switch {
+	case tglMulaiStr != "":
+		t, err := utils.ParseDate(tglMulaiStr)
+		if err != nil {
+			return "", fmt.Errorf("tanggal_mulai_kerja: %v", err)
+		}
+		tglMulai = t
+	case existing != nil:
+		tglMulai = existing.TanggalMulaiKerja
+	default:
+		return "", fmt.Errorf("tanggal_mulai_kerja wajib diisi")
+	}
+
+	var tglSelesai *time.Time
+	if tglSelesaiStr != "" {
+		t, err := utils.ParseDate(tglSelesaiStr)
+		if err != nil {
+			return "", fmt.Errorf("tanggal_selesai_kerja: %v", err)
+		}
+		tglSelesai = &t
+	} else if existing != nil && existing.TanggalSelesaiKerja != nil {
+		tglSelesai = existing.TanggalSelesaiKerja
+	}
+
+	if status != models.StatusPekerjaanSelesai {
+		if tglSelesaiStr != "" {
+			return "", fmt.Errorf("tanggal_selesai_kerja hanya boleh diisi jika status '%s'", models.StatusPekerjaanSelesai)
+		}
+		return "", nil
+	}
+
+	// aktif/cuti -> selesai tanpa tanggal selesai: isi dengan hari ini
+	if tglSelesai == nil {
+		today := utils.Today()
+		tglSelesai = &today
+		tglSelesaiStr = today.Format(utils.DateLayout)
+	}
+	if tglSelesai.Before(tglMulai) {
+		return "", fmt.Errorf("tanggal_selesai_kerja tidak boleh sebelum tanggal_mulai_kerja")
+	}
+	if tglSelesai.After(utils.Today()) {
+		return "", fmt.Errorf("tanggal_selesai_kerja tidak boleh di masa depan")
+	}
+	return tglSelesaiStr, nil
+}
+
+// resolveGaji melengkapi dan memvalidasi gaji. Jika gaji_min/gaji_max kosong,
+// gaji_range (teks bebas) di-parse menjadi nilai numerik.
+func resolveGaji(gaji *models.Gaji, gajiRange string) error {
+	if gaji.GajiMin == 0 && gaji.GajiMax == 0 {
+		if strings.TrimSpace(gajiRange) == "" {
+			return fmt.Errorf("gaji_min/gaji_max atau gaji_range wajib diisi")
+		}
+		parsed, err := utils.ParseSalaryRange(gajiRange)
+		if err != nil {
+			return fmt.Errorf("gaji_range: %v", err)
+		}
+		if gaji.GajiMataUang == "" {
+			gaji.GajiMataUang = parsed.GajiMataUang
+		}
+		if gaji.GajiPeriode == "" {
+			gaji.GajiPeriode = parsed.GajiPeriode
+		}
+		gaji.GajiMin, gaji.GajiMax = parsed.GajiMin, parsed.GajiMax
+	}
+
+	if gaji.GajiMataUang == "" {
+		gaji.GajiMataUang = "IDR"
+	}
+	gaji.GajiMataUang = strings.ToUpper(strings.TrimSpace(gaji.GajiMataUang))
+	if len(gaji.GajiMataUang) != 3 {
+		return fmt.Errorf("gaji_mata_uang harus berupa kode 3 huruf (mis. IDR)")
+	}
+
+	if gaji.GajiPeriode == "" {
+		gaji.GajiPeriode = models.GajiPeriodeBulanan
+	}
+	gaji.GajiPeriode = strings.ToLower(strings.TrimSpace(gaji.GajiPeriode))
+	if gaji.GajiPeriode != models.GajiPeriodeBulanan && gaji.GajiPeriode != models.GajiPeriodeTahunan {
+		return fmt.Errorf("gaji_periode harus '%s' atau '%s'", models.GajiPeriodeBulanan, models.GajiPeriodeTahunan)
+	}
+
+	if gaji.GajiMin < 0 || gaji.GajiMax < 0 {
+		return fmt.Errorf("gaji tidak boleh negatif")
+	}
+	if gaji.GajiMax != 0 && gaji.GajiMin > gaji.GajiMax {
+		return fmt.Errorf("gaji_min tidak boleh lebih besar dari gaji_max")
+	}
+	return nil
+}
+
+// parsePekerjaanFilter membaca filter daftar pekerjaan dari query string
+func parsePekerjaanFilter(c *fiber.Ctx) (models.PekerjaanFilter, error) {
+	filter := models.PekerjaanFilter{
+		Search:   c.Query("search", ""),
+		Status:   normalizeStatusPekerjaan(c.Query("status_pekerjaan", "")),
+		GajiMin:  int64(c.QueryInt("gaji_min", 0)),
+		GajiMax:  int64(c.QueryInt("gaji_max", 0)),
+		MataUang: strings.ToUpper(c.Query("gaji_mata_uang", "IDR")),
+		Periode:  strings.ToLower(c.Query("gaji_periode", models.GajiPeriodeBulanan)),
+	}
+
+	if filter.Periode != models.GajiPeriodeBulanan && filter.Periode != models.GajiPeriodeTahunan {
+		return filter, fmt.Errorf("gaji_periode harus '%s' atau '%s'", models.GajiPeriodeBulanan, models.GajiPeriodeTahunan)
+	}
+	if filter.Status != "" && !models.IsValidStatusPekerjaan(filter.Status) {
+		return filter, fmt.Errorf("status_pekerjaan tidak dikenal: %s", filter.Status)
+	}
+	if filter.GajiMin < 0 || filter.GajiMax < 0 {
+		return filter, fmt.Errorf("filter gaji tidak boleh negatif")
+	}
+	return filter, nil
+}
+
+// preparePekerjaanInput memvalidasi dan melengkapi input pekerjaan: perusahaan dan
+// industri dihubungkan ke master data, field wajib dicek, gaji diparse, lalu status
+// dan tanggal divalidasi. existing bernilai nil untuk pekerjaan baru.
+func (s *PekerjaanService) preparePekerjaanInput(ctx context.Context, existing *models.Pekerjaan, in *models.PekerjaanInput) error {
+	// Hubungkan ke master perusahaan (perusahaan_id atau cocokkan nama_perusahaan)
+	if err := s.resolvePerusahaan(ctx, &in.PerusahaanID, &in.NamaPerusahaan); err != nil {
+		return err
+	}
+
+	// Hubungkan ke taksonomi industri (kode_industri atau cocokkan bidang_industri)
+	if err := s.resolveIndustri(ctx, &in.KodeIndustri, &in.BidangIndustri); err != nil {
+		return err
+	}
+
+	// Validasi field wajib; tanggal mulai boleh kosong saat update (tidak diubah)
+	if in.NamaPerusahaan == "" || in.PosisiJabatan == "" ||
+		in.BidangIndustri == "" || in.LokasiKerja == "" ||
+		in.StatusPekerjaan == "" || in.DeskripsiPekerjaan == "" ||
+		(existing == nil && in.TanggalMulaiKerja == "") {
+		return errors.New("Semua field wajib diisi dan tidak boleh kosong")
+	}
+
+	// Validasi gaji (gaji_min/gaji_max atau gaji_range)
+	if err := resolveGaji(&in.Gaji, in.GajiRange); err != nil {
+		return err
+	}
+	in.GajiRange = ""
+
+	// Validasi status dan tanggal pekerjaan
+	tglSelesai, err := validatePekerjaanLifecycle(existing, in.StatusPekerjaan, in.TanggalMulaiKerja, in.TanggalSelesaiKerja)
+	if err != nil {
+		return err
+	}
+	if in.TanggalMulaiKerja != "" {
+		tglMulai, _ := utils.ParseDate(in.TanggalMulaiKerja)
+		in.TanggalMulaiKerja = tglMulai.Format(utils.DateLayout)
+	}
+	in.StatusPekerjaan = normalizeStatusPekerjaan(in.StatusPekerjaan)
+	in.TanggalSelesaiKerja = tglSelesai
+	return nil
+}
+
+// resolvePerusahaan menghubungkan pekerjaan ke master perusahaan. Jika perusahaan_id
+// diisi, perusahaan harus ada dan nama_perusahaan kosong diisi nama kanoniknya.
+// Jika kosong, nama_perusahaan dicocokkan dengan nama / alias yang sudah dinormalisasi.
+func (s *PekerjaanService) resolvePerusahaan(ctx context.Context, perusahaanID, namaPerusahaan *string) error {
+	if *perusahaanID != "" {
+		perusahaan, err := s.perusahaanRepo.GetByID(ctx, *perusahaanID)
+		if err != nil {
+			return err
+		}
+		if perusahaan == nil {
+			return fmt.Errorf("perusahaan dengan id %s tidak ditemukan", *perusahaanID)
+		}
+		if strings.TrimSpace(*namaPerusahaan) == "" {
+			*namaPerusahaan = perusahaan.Nama
+		}
+		return nil
+	}
+
+	perusahaan, err := s.perusahaanRepo.FindByNormalizedName(ctx, utils.NormalizeCompanyName(*namaPerusahaan))
+	if err != nil {
+		return err
+	}
+	if perusahaan != nil {
+		*perusahaanID = perusahaan.ID.Hex()
+	}
+	return nil
+}
+
+// resolveIndustri menghubungkan pekerjaan ke taksonomi KBLI. Jika kode_industri diisi,
+// kode harus ada dan bidang_industri kosong diisi judulnya. Jika kosong, bidang_industri
+// dicocokkan dengan judul / kata kunci taksonomi; teks yang tidak cocok tetap disimpan apa adanya.
+func (s *PekerjaanService) resolveIndustri(ctx context.Context, kodeIndustri, bidangIndustri *string) error {
+	if strings.TrimSpace(*kodeIndustri) != "" {
+		industri, err := s.industriRepo.GetByKode(ctx, *kodeIndustri)
+		if err != nil {
+			return err
+		}
+		if industri == nil {
+			return fmt.Errorf("kode_industri %s tidak ditemukan", *kodeIndustri)
+		}
+		*kodeIndustri = industri.Kode
+		if strings.TrimSpace(*bidangIndustri) == "" {
+			*bidangIndustri = industri.Judul
+		}
+		return nil
+	}
+
+	if strings.TrimSpace(*bidangIndustri) == "" {
+		return nil
+	}
+	taksonomi, err := s.industriRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	if industri := utils.MatchIndustri(*bidangIndustri, taksonomi); industri != nil {
+		*kodeIndustri = industri.Kode
+	}
+	return nil
+}
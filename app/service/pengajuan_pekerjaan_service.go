@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/config"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PengajuanPekerjaanService struct {
+	repo             repository.PengajuanPekerjaanRepository
+	pekerjaanRepo    repository.PekerjaanRepository
+	pekerjaanService *PekerjaanService
+	timeouts         config.Timeouts
+}
+
+func NewPengajuanPekerjaanService(r repository.PengajuanPekerjaanRepository, pekerjaanRepo repository.PekerjaanRepository, pekerjaanService *PekerjaanService, timeouts config.Timeouts) *PengajuanPekerjaanService {
+	return &PengajuanPekerjaanService{repo: r, pekerjaanRepo: pekerjaanRepo, pekerjaanService: pekerjaanService, timeouts: timeouts}
+}
+
+// Submit godoc
+// @Summary Ajukan pekerjaan (alumni)
+// @Description Alumni mengajukan pekerjaan baru, atau perubahan pekerjaan miliknya jika pekerjaan_id diisi. Pengajuan berstatus pending sampai direview admin
+// @Tags Pengajuan_Pekerjaan
+// @Accept json
+// @Produce json
+// @Param body body models.PengajuanPekerjaanRequest true "Data pekerjaan yang diajukan"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{} "masih ada pengajuan perubahan yang pending"
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/pengajuan-pekerjaan [post]
+func (s *PengajuanPekerjaanService) Submit(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	alumniID, _ := c.Locals("alumni_id").(string)
+	username, _ := c.Locals("username").(string)
+	alumniObjID, err := primitive.ObjectIDFromHex(alumniID)
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{"error": "Hanya akun alumni yang bisa mengajukan pekerjaan"})
+	}
+
+	var req models.PengajuanPekerjaanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Body request tidak valid"})
+	}
+
+	pengajuan := models.PengajuanPekerjaan{
+		AlumniID:     alumniObjID,
+		Jenis:        models.JenisPengajuanBaru,
+		DiajukanOleh: username,
+	}
+
+	var existing *models.Pekerjaan
+	if req.PekerjaanID != "" {
+		existing, err = s.pekerjaanRepo.GetByID(ctx, req.PekerjaanID)
+		if err != nil || existing == nil || existing.AlumniID != alumniObjID {
+			return c.Status(404).JSON(fiber.Map{"error": "Pekerjaan tidak ditemukan"})
+		}
+
+		pending, err := s.repo.HasPending(ctx, existing.ID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal memeriksa pengajuan sebelumnya"})
+		}
+		if pending {
+			return c.Status(409).JSON(fiber.Map{"error": "Masih ada pengajuan perubahan untuk pekerjaan ini yang belum direview"})
+		}
+
+		pengajuan.Jenis = models.JenisPengajuanUbah
+		pengajuan.PekerjaanID = &existing.ID
+	}
+
+	if err := s.pekerjaanService.preparePekerjaanInput(ctx, existing, &req.PekerjaanInput); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	pengajuan.Data = req.PekerjaanInput
+
+	if err := s.repo.Create(ctx, &pengajuan); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan pengajuan pekerjaan"})
+	}
+
+	slog.InfoContext(ctx, "Alumni mengajukan pekerjaan", "jenis", pengajuan.Jenis, "pengajuan_id", pengajuan.ID.Hex())
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"message": "Pengajuan pekerjaan berhasil dikirim dan menunggu review admin",
+		"data":    pengajuan,
+	})
+}
+
+// GetMine godoc
+// @Summary Pengajuan pekerjaan saya
+// @Description Daftar pengajuan milik alumni yang login beserta status dan komentar admin
+// @Tags Pengajuan_Pekerjaan
+// @Produce json
+// @Param status query string false "Filter status: pending, disetujui, ditolak"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/pengajuan-pekerjaan/saya [get]
+func (s *PengajuanPekerjaanService) GetMine(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	alumniID, _ := c.Locals("alumni_id").(string)
+	alumniObjID, err := primitive.ObjectIDFromHex(alumniID)
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{"error": "Hanya akun alumni yang punya pengajuan pekerjaan"})
+	}
+
+	status, err := parseStatusPengajuan(c.Query("status", ""))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	list, err := s.repo.GetAll(ctx, status, &alumniObjID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil pengajuan pekerjaan"})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": list})
+}
+
+// GetAll godoc
+// @Summary Antrian review pengajuan pekerjaan
+// @Description Daftar pengajuan dari yang terlama (admin only). Default hanya yang pending
+// @Tags Pengajuan_Pekerjaan
+// @Produce json
+// @Param status query string false "pending, disetujui, ditolak atau semua" default(pending)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/pengajuan-pekerjaan [get]
+func (s *PengajuanPekerjaanService) GetAll(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	status := c.Query("status", models.StatusPengajuanPending)
+	if status == "semua" {
+		status = ""
+	}
+	status, err := parseStatusPengajuan(status)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	list, err := s.repo.GetAll(ctx, status, nil)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil pengajuan pekerjaan"})
+	}
+	return c.JSON(fiber.Map{"success": true, "total": len(list), "data": list})
+}
+
+// GetByID godoc
+// @Summary Detail pengajuan pekerjaan
+// @Description Admin dapat melihat semua pengajuan, alumni hanya miliknya sendiri
+// @Tags Pengajuan_Pekerjaan
+// @Produce json
+// @Param id path string true "ID Pengajuan"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/pengajuan-pekerjaan/{id} [get]
+func (s *PengajuanPekerjaanService) GetByID(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	role, _ := c.Locals("role").(string)
+	alumniID, _ := c.Locals("alumni_id").(string)
+
+	pengajuan, err := s.repo.GetByID(ctx, c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if pengajuan == nil || (role != "admin" && pengajuan.AlumniID.Hex() != alumniID) {
+		return c.Status(404).JSON(fiber.Map{"error": "Pengajuan tidak ditemukan"})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": pengajuan})
+}
+
+// Approve godoc
+// @Summary Setujui pengajuan pekerjaan
+// @Description Data pengajuan divalidasi ulang lalu disimpan ke pekerjaan_alumni (baru) atau menimpa pekerjaan yang diubah (admin only)
+// @Tags Pengajuan_Pekerjaan
+// @Accept json
+// @Produce json
+// @Param id path string true "ID Pengajuan"
+// @Param body body models.ReviewPengajuanRequest false "Komentar admin (opsional)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{} "pengajuan sudah direview atau pekerjaan sudah dihapus"
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/pengajuan-pekerjaan/{id}/approve [post]
+func (s *PengajuanPekerjaanService) Approve(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	username, _ := c.Locals("username").(string)
+	var req models.ReviewPengajuanRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Body request tidak valid"})
+		}
+	}
+
+	pengajuan, status, err := s.getPending(ctx, c.Params("id"))
+	if err != nil {
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Validasi ulang terhadap kondisi pekerjaan saat ini (status bisa sudah berubah)
+	var existing *models.Pekerjaan
+	if pengajuan.Jenis == models.JenisPengajuanUbah {
+		existing, err = s.pekerjaanRepo.GetByID(ctx, pengajuan.PekerjaanID.Hex())
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil pekerjaan yang diubah"})
+		}
+		if existing == nil {
+			return c.Status(409).JSON(fiber.Map{"error": "Pekerjaan yang diubah sudah tidak ada, tolak pengajuan ini"})
+		}
+	}
+	data := pengajuan.Data
+	if err := s.pekerjaanService.preparePekerjaanInput(ctx, existing, &data); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Tandai disetujui lebih dulu agar pengajuan tidak diproses dua kali
+	if err := s.repo.Review(ctx, pengajuan.ID, models.StatusPengajuanDisetujui, strings.TrimSpace(req.Komentar), username); err != nil {
+		return s.reviewError(c, err)
+	}
+
+	var pekerjaan *models.Pekerjaan
+	if existing != nil {
+		pekerjaan, err = s.pekerjaanRepo.Update(ctx, existing.ID.Hex(), &models.UpdatePekerjaanRequest{PekerjaanInput: data})
+	} else {
+		pekerjaan, err = s.pekerjaanRepo.Create(ctx, &models.CreatePekerjaanRequest{AlumniID: pengajuan.AlumniID.Hex(), PekerjaanInput: data})
+		if err == nil {
+			err = s.repo.SetPekerjaanID(ctx, pengajuan.ID, pekerjaan.ID)
+		}
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal menyimpan pekerjaan dari pengajuan", "pengajuan_id", pengajuan.ID.Hex(), "err", err)
+		if pekerjaan == nil {
+			if reopenErr := s.repo.Reopen(ctx, pengajuan.ID); reopenErr != nil {
+				slog.ErrorContext(ctx, "Gagal mengembalikan pengajuan ke pending", "pengajuan_id", pengajuan.ID.Hex(), "err", reopenErr)
+			}
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan data pekerjaan dari pengajuan"})
+	}
+
+	slog.InfoContext(ctx, "Admin menyetujui pengajuan pekerjaan", "pengajuan_id", pengajuan.ID.Hex())
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Pengajuan disetujui",
+		"data":    pekerjaan,
+	})
+}
+
+// Reject godoc
+// @Summary Tolak pengajuan pekerjaan
+// @Description Menolak pengajuan dengan komentar wajib yang akan dilihat alumni (admin only)
+// @Tags Pengajuan_Pekerjaan
+// @Accept json
+// @Produce json
+// @Param id path string true "ID Pengajuan"
+// @Param body body models.ReviewPengajuanRequest true "Alasan penolakan"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{} "pengajuan sudah direview"
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/pengajuan-pekerjaan/{id}/reject [post]
+func (s *PengajuanPekerjaanService) Reject(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	username, _ := c.Locals("username").(string)
+	var req models.ReviewPengajuanRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.Komentar) == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "komentar wajib diisi saat menolak pengajuan"})
+	}
+
+	pengajuan, status, err := s.getPending(ctx, c.Params("id"))
+	if err != nil {
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := s.repo.Review(ctx, pengajuan.ID, models.StatusPengajuanDitolak, strings.TrimSpace(req.Komentar), username); err != nil {
+		return s.reviewError(c, err)
+	}
+
+	slog.InfoContext(ctx, "Admin menolak pengajuan pekerjaan", "pengajuan_id", pengajuan.ID.Hex())
+	return c.JSON(fiber.Map{"success": true, "message": "Pengajuan ditolak"})
+}
+
+// getPending mengambil pengajuan yang masih bisa direview beserta status HTTP jika gagal
+func (s *PengajuanPekerjaanService) getPending(ctx context.Context, id string) (*models.PengajuanPekerjaan, int, error) {
+	pengajuan, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, 400, err
+	}
+	if pengajuan == nil {
+		return nil, 404, errors.New("Pengajuan tidak ditemukan")
+	}
+	if pengajuan.Status != models.StatusPengajuanPending {
+		return nil, 409, errors.New("Pengajuan sudah " + pengajuan.Status)
+	}
+	return pengajuan, 0, nil
+}
+
+func (s *PengajuanPekerjaanService) reviewError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(409).JSON(fiber.Map{"error": "Pengajuan sudah direview"})
+	}
+	return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan hasil review"})
+}
+
+// parseStatusPengajuan memvalidasi filter status (kosong = semua status)
+func parseStatusPengajuan(status string) (string, error) {
+	switch status {
+	case "", models.StatusPengajuanPending, models.StatusPengajuanDisetujui, models.StatusPengajuanDitolak:
+		return status, nil
+	}
+	return "", errors.New("status harus pending, disetujui atau ditolak")
+}
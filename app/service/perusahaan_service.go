@@ -0,0 +1,416 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/config"
+	"crud-app/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Skor minimal kemiripan nama agar muncul sebagai saran pencocokan
+const minSkorSaranPerusahaan = 0.5
+
+type PerusahaanService struct {
+	repo     repository.PerusahaanRepository
+	timeouts config.Timeouts
+}
+
+func NewPerusahaanService(r repository.PerusahaanRepository, timeouts config.Timeouts) *PerusahaanService {
+	return &PerusahaanService{repo: r, timeouts: timeouts}
+}
+
+// GetAll godoc
+// @Summary Daftar perusahaan
+// @Description Mengambil master data perusahaan, dapat dicari berdasarkan nama atau alias
+// @Tags Perusahaan
+// @Produce json
+// @Param search query string false "Kata kunci nama / alias"
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/perusahaan [get]
+func (s *PerusahaanService) GetAll(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	list, err := s.repo.GetAll(ctx, c.Query("search", ""))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data perusahaan"})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": list})
+}
+
+// GetByID godoc
+// @Summary Detail perusahaan
+// @Description Mengambil data perusahaan beserta semua alumni yang pernah / sedang bekerja di sana
+// @Tags Perusahaan
+// @Produce json
+// @Param id path string true "ID Perusahaan"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/perusahaan/{id} [get]
+func (s *PerusahaanService) GetByID(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(c.Params("id")); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "ID perusahaan tidak valid"})
+	}
+	perusahaan, err := s.repo.GetByID(ctx, c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+	if perusahaan == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Perusahaan tidak ditemukan"})
+	}
+
+	alumni, err := s.repo.GetAlumni(ctx, perusahaan.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil alumni perusahaan"})
+	}
+	if alumni == nil {
+		alumni = []models.AlumniPerusahaan{}
+	}
+
+	return c.JSON(fiber.Map{"success": true, "data": perusahaan, "alumni": alumni})
+}
+
+// Create godoc
+// @Summary Tambah perusahaan
+// @Description Menambah master data perusahaan (admin only). Nama dan alias harus unik setelah dinormalisasi
+// @Tags Perusahaan
+// @Accept json
+// @Produce json
+// @Param body body models.PerusahaanRequest true "Data perusahaan"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/perusahaan [post]
+func (s *PerusahaanService) Create(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	var req models.PerusahaanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Body request tidak valid"})
+	}
+
+	perusahaan := &models.Perusahaan{}
+	if err := applyPerusahaanRequest(perusahaan, &req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := s.checkNamaUnik(ctx, perusahaan); err != nil {
+		return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := s.repo.Create(ctx, perusahaan); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menambah perusahaan"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"message": "Perusahaan berhasil ditambahkan",
+		"data":    perusahaan,
+	})
+}
+
+// Update godoc
+// @Summary Update perusahaan
+// @Description Mengubah nama kanonik, alias, industri dan lokasi perusahaan (admin only)
+// @Tags Perusahaan
+// @Accept json
+// @Produce json
+// @Param id path string true "ID Perusahaan"
+// @Param body body models.PerusahaanRequest true "Data perusahaan"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/perusahaan/{id} [put]
+func (s *PerusahaanService) Update(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	var req models.PerusahaanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Body request tidak valid"})
+	}
+
+	if _, err := primitive.ObjectIDFromHex(c.Params("id")); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "ID perusahaan tidak valid"})
+	}
+	perusahaan, err := s.repo.GetByID(ctx, c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+	if perusahaan == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Perusahaan tidak ditemukan"})
+	}
+
+	if err := applyPerusahaanRequest(perusahaan, &req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := s.checkNamaUnik(ctx, perusahaan); err != nil {
+		return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := s.repo.Update(ctx, perusahaan); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal update perusahaan"})
+	}
+
+	return c.JSON(fiber.Map{"success": true, "data": perusahaan})
+}
+
+// Match godoc
+// @Summary Cocokkan pekerjaan dengan master perusahaan
+// @Description Menghubungkan pekerjaan yang belum punya perusahaan_id berdasarkan nama / alias yang dinormalisasi. Nama yang tidak cocok dikembalikan beserta saran perusahaan yang mirip (admin only)
+// @Tags Perusahaan
+// @Produce json
+// @Param dry_run query bool false "Hanya laporan, tidak mengubah data" default(true)
+// @Param create_missing query bool false "Buat perusahaan baru untuk nama yang tidak cocok dan tidak punya saran" default(false)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/perusahaan/match [post]
+func (s *PerusahaanService) Match(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Batch)
+	defer cancel()
+
+	dryRun := c.QueryBool("dry_run", true)
+	createMissing := c.QueryBool("create_missing", false)
+	slog.InfoContext(ctx, "Admin menjalankan pencocokan perusahaan", "dry_run", dryRun, "create_missing", createMissing)
+
+	companies, err := s.repo.GetAll(ctx, "")
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data perusahaan"})
+	}
+	byNormal := make(map[string]*models.Perusahaan)
+	for i := range companies {
+		byNormal[companies[i].NamaNormal] = &companies[i]
+		for _, alias := range companies[i].AliasNormal {
+			byNormal[alias] = &companies[i]
+		}
+	}
+
+	names, err := s.repo.GetUnlinkedNames(ctx)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil nama perusahaan pada pekerjaan"})
+	}
+
+	matched := []models.HasilMatchPerusahaan{}
+	unmatched := []models.HasilMatchPerusahaan{}
+	created := []models.Perusahaan{}
+	var linked int64
+
+	for _, n := range names {
+		normal := utils.NormalizeCompanyName(n.Nama)
+		if normal == "" {
+			continue
+		}
+		hasil := models.HasilMatchPerusahaan{Nama: n.Nama, JumlahPekerjaan: n.JumlahPekerjaan}
+
+		perusahaan := byNormal[normal]
+		if perusahaan == nil {
+			hasil.Saran = saranPerusahaan(normal, companies)
+			if !createMissing || len(hasil.Saran) > 0 {
+				unmatched = append(unmatched, hasil)
+				continue
+			}
+
+			// Nama dengan bentuk normal yang sama (mis. "PT Telkom" dan "PT. TELKOM") jadi satu perusahaan
+			perusahaan = &models.Perusahaan{Nama: strings.TrimSpace(n.Nama), NamaNormal: normal, Alias: []string{}, AliasNormal: []string{}}
+			if !dryRun {
+				if err := s.repo.Create(ctx, perusahaan); err != nil {
+					return c.Status(500).JSON(fiber.Map{"error": "Gagal membuat perusahaan baru"})
+				}
+			}
+			byNormal[normal] = perusahaan
+			created = append(created, *perusahaan)
+		}
+
+		hasil.PerusahaanID = perusahaan.ID
+		hasil.PerusahaanNama = perusahaan.Nama
+		matched = append(matched, hasil)
+
+		if !dryRun {
+			count, err := s.repo.LinkPekerjaan(ctx, []string{n.Nama}, perusahaan.ID)
+			if err != nil {
+				return c.Status(500).JSON(fiber.Map{"error": "Gagal menghubungkan pekerjaan ke perusahaan"})
+			}
+			linked += count
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success":          true,
+		"dry_run":          dryRun,
+		"matched":          matched,
+		"unmatched":        unmatched,
+		"created":          created,
+		"linked_pekerjaan": linked,
+	})
+}
+
+// Merge godoc
+// @Summary Gabungkan perusahaan duplikat
+// @Description Menggabungkan perusahaan source_ids ke perusahaan tujuan: nama dan alias menjadi alias, pekerjaan dipindahkan, lalu perusahaan sumber dihapus (admin only)
+// @Tags Perusahaan
+// @Accept json
+// @Produce json
+// @Param id path string true "ID Perusahaan tujuan"
+// @Param body body models.MergePerusahaanRequest true "ID perusahaan yang digabungkan"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/perusahaan/{id}/merge [post]
+func (s *PerusahaanService) Merge(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Batch)
+	defer cancel()
+
+	var req models.MergePerusahaanRequest
+	if err := c.BodyParser(&req); err != nil || len(req.SourceIDs) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "source_ids wajib diisi"})
+	}
+
+	if _, err := primitive.ObjectIDFromHex(c.Params("id")); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "ID perusahaan tidak valid"})
+	}
+	target, err := s.repo.GetByID(ctx, c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+	if target == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Perusahaan tujuan tidak ditemukan"})
+	}
+
+	var sourceIDs []primitive.ObjectID
+	for _, id := range req.SourceIDs {
+		source, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		if source == nil {
+			return c.Status(404).JSON(fiber.Map{"error": fmt.Sprintf("Perusahaan %s tidak ditemukan", id)})
+		}
+		if source.ID == target.ID {
+			return c.Status(400).JSON(fiber.Map{"error": "Perusahaan tidak bisa digabungkan dengan dirinya sendiri"})
+		}
+
+		target.Alias = append(target.Alias, source.Nama)
+		target.Alias = append(target.Alias, source.Alias...)
+		sourceIDs = append(sourceIDs, source.ID)
+	}
+
+	merged := models.PerusahaanRequest{
+		Nama:           target.Nama,
+		Alias:          target.Alias,
+		BidangIndustri: target.BidangIndustri,
+		Lokasi:         target.Lokasi,
+	}
+	if err := applyPerusahaanRequest(target, &merged); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	moved, err := s.repo.ReassignPekerjaan(ctx, sourceIDs, target.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal memindahkan pekerjaan"})
+	}
+	if err := s.repo.Update(ctx, target); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal update perusahaan tujuan"})
+	}
+	if err := s.repo.Delete(ctx, sourceIDs); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghapus perusahaan sumber"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success":          true,
+		"message":          "Perusahaan berhasil digabungkan",
+		"data":             target,
+		"moved_pekerjaan":  moved,
+		"merged_companies": len(sourceIDs),
+	})
+}
+
+// applyPerusahaanRequest menyalin request ke model dan menghitung nama/alias normal.
+// Alias yang sama dengan nama kanonik atau duplikat setelah dinormalisasi dibuang.
+func applyPerusahaanRequest(perusahaan *models.Perusahaan, req *models.PerusahaanRequest) error {
+	nama := strings.TrimSpace(req.Nama)
+	normal := utils.NormalizeCompanyName(nama)
+	if normal == "" {
+		return fmt.Errorf("nama perusahaan wajib diisi")
+	}
+
+	perusahaan.Nama = nama
+	perusahaan.NamaNormal = normal
+	perusahaan.BidangIndustri = strings.TrimSpace(req.BidangIndustri)
+	perusahaan.Lokasi = strings.TrimSpace(req.Lokasi)
+	perusahaan.Alias = []string{}
+	perusahaan.AliasNormal = []string{}
+
+	seen := map[string]bool{normal: true}
+	for _, alias := range req.Alias {
+		alias = strings.TrimSpace(alias)
+		aliasNormal := utils.NormalizeCompanyName(alias)
+		if aliasNormal == "" || seen[aliasNormal] {
+			continue
+		}
+		seen[aliasNormal] = true
+		perusahaan.Alias = append(perusahaan.Alias, alias)
+		perusahaan.AliasNormal = append(perusahaan.AliasNormal, aliasNormal)
+	}
+	return nil
+}
+
+// checkNamaUnik memastikan nama dan alias tidak dipakai perusahaan lain
+func (s *PerusahaanService) checkNamaUnik(ctx context.Context, perusahaan *models.Perusahaan) error {
+	for _, normal := range append([]string{perusahaan.NamaNormal}, perusahaan.AliasNormal...) {
+		existing, err := s.repo.FindByNormalizedName(ctx, normal)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.ID != perusahaan.ID {
+			return fmt.Errorf("nama '%s' sudah dipakai perusahaan %s (%s)", normal, existing.Nama, existing.ID.Hex())
+		}
+	}
+	return nil
+}
+
+// saranPerusahaan mencari maksimal 3 perusahaan dengan nama / alias paling mirip
+func saranPerusahaan(normal string, companies []models.Perusahaan) []models.SaranPerusahaan {
+	var saran []models.SaranPerusahaan
+	for _, p := range companies {
+		best := utils.CompanyNameSimilarity(normal, p.NamaNormal)
+		for _, alias := range p.AliasNormal {
+			if skor := utils.CompanyNameSimilarity(normal, alias); skor > best {
+				best = skor
+			}
+		}
+		if best >= minSkorSaranPerusahaan {
+			saran = append(saran, models.SaranPerusahaan{PerusahaanID: p.ID, Nama: p.Nama, Skor: best})
+		}
+	}
+
+	sort.Slice(saran, func(i, j int) bool { return saran[i].Skor > saran[j].Skor })
+	if len(saran) > 3 {
+		saran = saran[:3]
+	}
+	return saran
+}
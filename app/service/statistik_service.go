@@ -0,0 +1,273 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/config"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StatistikService struct {
+	repo     repository.StatistikRepository
+	timeouts config.Timeouts
+}
+
+func NewStatistikService(r repository.StatistikRepository, timeouts config.Timeouts) *StatistikService {
+	return &StatistikService{repo: r, timeouts: timeouts}
+}
+
+// Kolom alumni yang boleh dipakai untuk pengelompokan
+var statistikGroupByWhitelist = map[string]bool{
+	"": true, "jurusan": true, "angkatan": true, "tahun_lulus": true,
+}
+
+// GetKeterserapan godoc
+// @Summary Statistik keterserapan kerja alumni
+// @Description Persentase alumni yang pernah / sedang bekerja (status aktif atau cuti), dapat dikelompokkan per jurusan, angkatan atau tahun_lulus (admin only)
+// @Tags Statistik
+// @Produce json
+// @Param group_by query string false "Kelompok: jurusan, angkatan, tahun_lulus (kosong = total)"
+// @Param search query string false "Kata kunci pencarian alumni"
+// @Param jurusan query string false "Filter jurusan"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/statistik/keterserapan [get]
+func (s *StatistikService) GetKeterserapan(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Report)
+	defer cancel()
+
+	groupBy := c.Query("group_by", "")
+	if !statistikGroupByWhitelist[groupBy] {
+		return c.Status(400).JSON(fiber.Map{"error": "group_by harus salah satu dari: jurusan, angkatan, tahun_lulus"})
+	}
+
+	data, err := s.repo.Keterserapan(ctx, parseAlumniFilter(c), groupBy)
+	if err != nil {
+		slog.ErrorContext(ctx, "Statistik keterserapan gagal", "err", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung statistik keterserapan"})
+	}
+
+	for i := range data {
+		data[i].PersentaseBekerja = persen(data[i].Bekerja, data[i].TotalAlumni)
+		data[i].PersentaseSaatIni = persen(data[i].BekerjaSaatIni, data[i].TotalAlumni)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "group_by": groupBy, "data": data})
+}
+
+// GetMasaTunggu godoc
+// @Summary Statistik masa tunggu kerja pertama
+// @Description Rata-rata, minimum, maksimum masa tunggu (bulan) dari kelulusan ke pekerjaan pertama serta jumlah alumni dengan masa tunggu <= 6 bulan (admin only)
+// @Tags Statistik
+// @Produce json
+// @Param group_by query string false "Kelompok: jurusan, angkatan, tahun_lulus (kosong = total)"
+// @Param search query string false "Kata kunci pencarian alumni"
+// @Param jurusan query string false "Filter jurusan"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/statistik/masa-tunggu [get]
+func (s *StatistikService) GetMasaTunggu(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Report)
+	defer cancel()
+
+	groupBy := c.Query("group_by", "")
+	if !statistikGroupByWhitelist[groupBy] {
+		return c.Status(400).JSON(fiber.Map{"error": "group_by harus salah satu dari: jurusan, angkatan, tahun_lulus"})
+	}
+
+	data, err := s.repo.MasaTunggu(ctx, parseAlumniFilter(c), groupBy)
+	if err != nil {
+		slog.ErrorContext(ctx, "Statistik masa tunggu gagal", "err", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung statistik masa tunggu"})
+	}
+
+	for i := range data {
+		data[i].RataRataBulan = math.Round(data[i].RataRataBulan*100) / 100
+		data[i].PersentaseMaksEnam = persen(data[i].MaksEnamBulan, data[i].JumlahAlumni)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "group_by": groupBy, "data": data})
+}
+
+// GetDistribusiIndustri godoc
+// @Summary Statistik distribusi bidang industri
+// @Description Jumlah pekerjaan dan alumni per kode KBLI (kategori / golongan_pokok) atau per teks bidang_industri (admin only)
+// @Tags Statistik
+// @Produce json
+// @Param level query string false "kategori, golongan_pokok atau bidang_industri (teks bebas)" default(kategori)
+// @Param search query string false "Kata kunci pencarian (alumni dan pekerjaan)"
+// @Param jurusan query string false "Filter jurusan"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param status_pekerjaan query string false "Filter status: aktif, cuti, selesai"
+// @Param gaji_min query int false "Batas bawah gaji"
+// @Param gaji_max query int false "Batas atas gaji"
+// @Param gaji_periode query string false "Periode gaji filter" default(bulanan)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/statistik/industri [get]
+func (s *StatistikService) GetDistribusiIndustri(c *fiber.Ctx) error {
+	level := c.Query("level", models.LevelIndustriKategori)
+	switch level {
+	case "bidang_industri":
+		return s.distribusi(c, "bidang_industri")
+	case models.LevelIndustriKategori, models.LevelIndustriGolonganPokok:
+	default:
+		return c.Status(400).JSON(fiber.Map{"error": "level harus kategori, golongan_pokok atau bidang_industri"})
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Report)
+	defer cancel()
+
+	pf, err := parsePekerjaanFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	data, err := s.repo.DistribusiIndustri(ctx, parseAlumniFilter(c), pf, level)
+	if err != nil {
+		slog.ErrorContext(ctx, "Statistik distribusi industri gagal", "level", level, "err", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung statistik distribusi"})
+	}
+	return s.distribusiResponse(c, data)
+}
+
+// GetDistribusiLokasi godoc
+// @Summary Statistik distribusi lokasi kerja
+// @Description Jumlah pekerjaan dan alumni per lokasi_kerja (admin only)
+// @Tags Statistik
+// @Produce json
+// @Param search query string false "Kata kunci pencarian (alumni dan pekerjaan)"
+// @Param jurusan query string false "Filter jurusan"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param status_pekerjaan query string false "Filter status: aktif, cuti, selesai"
+// @Param gaji_min query int false "Batas bawah gaji"
+// @Param gaji_max query int false "Batas atas gaji"
+// @Param gaji_periode query string false "Periode gaji filter" default(bulanan)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/statistik/lokasi [get]
+func (s *StatistikService) GetDistribusiLokasi(c *fiber.Ctx) error {
+	return s.distribusi(c, "lokasi_kerja")
+}
+
+func (s *StatistikService) distribusi(c *fiber.Ctx, field string) error {
+	ctx, cancel := requestContext(c, s.timeouts.Report)
+	defer cancel()
+
+	pf, err := parsePekerjaanFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	data, err := s.repo.Distribusi(ctx, parseAlumniFilter(c), pf, field)
+	if err != nil {
+		slog.ErrorContext(ctx, "Statistik distribusi gagal", "field", field, "err", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung statistik distribusi"})
+	}
+	return s.distribusiResponse(c, data)
+}
+
+func (s *StatistikService) distribusiResponse(c *fiber.Ctx, data []models.StatistikDistribusi) error {
+	total := 0
+	for _, d := range data {
+		total += d.JumlahPekerjaan
+	}
+	for i := range data {
+		data[i].Persentase = persen(data[i].JumlahPekerjaan, total)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "total": total, "data": data})
+}
+
+// GetDistribusiGaji godoc
+// @Summary Statistik rentang gaji
+// @Description Jumlah pekerjaan per rentang gaji bulanan (IDR, titik tengah gaji_min/gaji_max, gaji tahunan dibagi 12) (admin only)
+// @Tags Statistik
+// @Produce json
+// @Param search query string false "Kata kunci pencarian (alumni dan pekerjaan)"
+// @Param jurusan query string false "Filter jurusan"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param status_pekerjaan query string false "Filter status: aktif, cuti, selesai"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /unair/statistik/gaji [get]
+func (s *StatistikService) GetDistribusiGaji(c *fiber.Ctx) error {
+	ctx, cancel := requestContext(c, s.timeouts.Report)
+	defer cancel()
+
+	pf, err := parsePekerjaanFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	counts, err := s.repo.DistribusiGaji(ctx, parseAlumniFilter(c), pf)
+	if err != nil {
+		slog.ErrorContext(ctx, "Statistik gaji gagal", "err", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung statistik gaji"})
+	}
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+
+	bounds := repository.SalaryBandBoundaries
+	data := make([]models.StatistikGaji, 0, len(bounds))
+	for i, lower := range bounds {
+		var upper int64
+		if i+1 < len(bounds) {
+			upper = bounds[i+1]
+		}
+		data = append(data, models.StatistikGaji{
+			Band:       salaryBandLabel(lower, upper),
+			BatasBawah: lower,
+			BatasAtas:  upper,
+			Jumlah:     counts[lower],
+			Persentase: persen(counts[lower], total),
+		})
+	}
+
+	return c.JSON(fiber.Map{"success": true, "mata_uang": "IDR", "periode": models.GajiPeriodeBulanan, "total": total, "data": data})
+}
+
+func salaryBandLabel(lower, upper int64) string {
+	juta := func(v int64) string { return fmt.Sprintf("%g", float64(v)/1e6) }
+	switch {
+	case upper == 0:
+		return fmt.Sprintf(">= %s juta", juta(lower))
+	case lower == 0:
+		return fmt.Sprintf("< %s juta", juta(upper))
+	default:
+		return fmt.Sprintf("%s - %s juta", juta(lower), juta(upper))
+	}
+}
+
+// persen menghitung persentase dengan 2 angka desimal
+func persen(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*10000/float64(total)) / 100
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	models "crud-app/app/model"
+	"crud-app/config"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Batas jumlah baris laporan pemakaian storage terbesar
+const (
+	defaultTopUsageLimit = 10
+	maxTopUsageLimit     = 100
+)
+
+// @Summary Pemakaian storage
+// @Description Total byte dan jumlah file per kategori beserta kuota yang berlaku. User melihat miliknya sendiri, admin bisa memilih user lewat user_id
+// @Tags Files
+// @Produce json
+// @Param user_id query string false "User ID (khusus admin)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/usage [get]
+func (s *fileService) GetUsage(c *fiber.Ctx) error {
+
+	userIDStr, _ := c.Locals("user_id").(string)
+	if role, _ := c.Locals("role").(string); role == "admin" && c.Query("user_id") != "" {
+		userIDStr = c.Query("user_id")
+	}
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid user_id format",
+		})
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	usage, err := s.usageFor(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal menghitung pemakaian storage", "target_user_id", userIDStr, "err", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to get storage usage",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    usage,
+	})
+}
+
+// @Summary Pengguna storage terbesar
+// @Description Laporan user dengan pemakaian storage terbesar, dihitung dari collection files (admin only)
+// @Tags Files
+// @Produce json
+// @Param limit query int false "Jumlah user (default 10, maks 100)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/usage/top [get]
+func (s *fileService) GetTopUsage(c *fiber.Ctx) error {
+
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	limit := c.QueryInt("limit", defaultTopUsageLimit)
+	if limit <= 0 {
+		limit = defaultTopUsageLimit
+	}
+	if limit > maxTopUsageLimit {
+		limit = maxTopUsageLimit
+	}
+
+	list, err := s.repo.TopUsage(ctx, int64(limit))
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal menghitung laporan pemakaian storage", "err", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to get storage usage report",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    list,
+	})
+}
+
+// @Summary Atur kuota storage user
+// @Description Menetapkan kuota khusus user yang menimpa kuota bawaan role-nya (QUOTA_<ROLE>_MB / QUOTA_<ROLE>_FILES). Nilai 0 berarti tanpa batas (admin only)
+// @Tags Files
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param body body models.SetStorageQuotaRequest true "Kuota"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/quota/{user_id} [put]
+func (s *fileService) SetQuota(c *fiber.Ctx) error {
+
+	userID, err := primitive.ObjectIDFromHex(c.Params("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid user_id format",
+		})
+	}
+
+	var req models.SetStorageQuotaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid request body",
+		})
+	}
+	if req.MaxBytes < 0 || req.MaxFiles < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "max_bytes and max_files must not be negative",
+		})
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	user, err := s.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to get user",
+		})
+	}
+	if user == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "User not found",
+		})
+	}
+
+	admin, _ := c.Locals("username").(string)
+	quota := &models.StorageQuota{
+		UserID:    userID,
+		MaxBytes:  req.MaxBytes,
+		MaxFiles:  req.MaxFiles,
+		Catatan:   strings.TrimSpace(req.Catatan),
+		UpdatedBy: admin,
+	}
+	if err := s.quotaRepo.Set(ctx, quota); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to save quota",
+		})
+	}
+
+	recordAudit(c, s.auditRepo, s.timeouts.Write, models.AuditAksiUbahKuota, "storage_quotas", userID.Hex(), map[string]interface{}{
+		"max_bytes": quota.MaxBytes,
+		"max_files": quota.MaxFiles,
+		"catatan":   quota.Catatan,
+	})
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Quota updated successfully",
+		"data":    quota,
+	})
+}
+
+// @Summary Hapus kuota khusus user
+// @Description Menghapus kuota khusus sehingga user kembali memakai kuota bawaan role-nya (admin only)
+// @Tags Files
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/quota/{user_id} [delete]
+func (s *fileService) DeleteQuota(c *fiber.Ctx) error {
+
+	userID, err := primitive.ObjectIDFromHex(c.Params("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid user_id format",
+		})
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	deleted, err := s.quotaRepo.Delete(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to delete quota",
+		})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"message": "User has no custom quota",
+		})
+	}
+
+	recordAudit(c, s.auditRepo, s.timeouts.Write, models.AuditAksiUbahKuota, "storage_quotas", userID.Hex(), map[string]interface{}{
+		"dihapus": true,
+	})
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Quota reset to role default",
+	})
+}
+
+// usageFor menghitung pemakaian storage user beserta kuota yang berlaku
+func (s *fileService) usageFor(ctx context.Context, userID primitive.ObjectID) (*models.StorageUsage, error) {
+	perKategori, err := s.repo.UsageByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	limit, source, err := s.quotaFor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &models.StorageUsage{
+		UserID:      userID.Hex(),
+		MaxBytes:    limit.MaxBytes,
+		MaxFiles:    limit.MaxFiles,
+		QuotaSource: source,
+		PerKategori: perKategori,
+	}
+	for _, k := range perKategori {
+		usage.UsedBytes += k.Bytes
+		usage.FileCount += k.FileCount
+	}
+	return usage, nil
+}
+
+// quotaFor mengembalikan kuota khusus user jika ada, jika tidak kuota bawaan role-nya
+func (s *fileService) quotaFor(ctx context.Context, userID primitive.ObjectID) (config.QuotaLimit, string, error) {
+	quota, err := s.quotaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return config.QuotaLimit{}, "", err
+	}
+	if quota != nil {
+		return config.QuotaLimit{MaxBytes: quota.MaxBytes, MaxFiles: quota.MaxFiles}, models.QuotaSourceUser, nil
+	}
+
+	user, err := s.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return config.QuotaLimit{}, "", err
+	}
+	role := ""
+	if user != nil {
+		role = user.Role
+	}
+	return s.quotas.ForRole(role), models.QuotaSourceRole, nil
+}
+
+// checkQuota menolak upload (413) jika file baru berukuran size melebihi kuota pemilik
+func (s *fileService) checkQuota(ctx context.Context, userID primitive.ObjectID, size int64) error {
+	usage, err := s.usageFor(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal mengecek kuota storage", "target_user_id", userID.Hex(), "err", err)
+		return newUploadError(fiber.StatusInternalServerError, "Failed to check storage quota")
+	}
+	if usage.MaxFiles > 0 && usage.FileCount+1 > usage.MaxFiles {
+		return newUploadError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Storage quota exceeded (max %d files)", usage.MaxFiles))
+	}
+	if usage.MaxBytes > 0 && usage.UsedBytes+size > usage.MaxBytes {
+		return newUploadError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Storage quota exceeded (%d of %d bytes used)", usage.UsedBytes, usage.MaxBytes))
+	}
+	return nil
+}
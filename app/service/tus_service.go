@@ -0,0 +1,458 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	models "crud-app/app/model"
+	"crud-app/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Upload tus yang tidak diselesaikan dalam waktu ini dihapus
+const tusUploadExpiry = 24 * time.Hour
+
+// Ekstensi protokol tus yang didukung
+const tusExtensions = "creation,termination,expiration"
+
+// @Summary Info server tus
+// @Description Discovery upload resumable (tus 1.0.0): versi, ekstensi yang didukung dan ukuran maksimal
+// @Tags Files
+// @Success 204 "Header Tus-Version, Tus-Extension dan Tus-Max-Size"
+// @Router /files/tus [options]
+func (s *fileService) TusOptions(c *fiber.Ctx) error {
+	var maxSize int64
+	for _, cat := range s.categories {
+		maxSize = max(maxSize, cat.tusMaxSize)
+	}
+
+	c.Set("Tus-Resumable", models.TusVersion)
+	c.Set("Tus-Version", models.TusVersion)
+	c.Set("Tus-Extension", tusExtensions)
+	c.Set("Tus-Max-Size", strconv.FormatInt(maxSize, 10))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Mulai upload resumable (tus)
+// @Description Membuat upload tus baru. Upload-Metadata wajib berisi kategori (foto atau sertifikat), filename dan filetype (base64); admin juga wajib mengisi user_id. Batas ukuran per kategori diatur lewat TUS_MAX_FOTO_MB dan TUS_MAX_SERTIFIKAT_MB
+// @Tags Files
+// @Param Tus-Resumable header string true "Versi protokol, 1.0.0"
+// @Param Upload-Length header int true "Ukuran file (byte)"
+// @Param Upload-Metadata header string true "Metadata tus, mis. kategori c2VydGlmaWthdA==,filename YS5wZGY=,filetype YXBwbGljYXRpb24vcGRm"
+// @Success 201 "Header Location berisi URL upload"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 412 "Versi tus tidak didukung"
+// @Failure 413 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/tus [post]
+func (s *fileService) TusCreate(c *fiber.Ctx) error {
+	if !tusVersionSupported(c) {
+		return tusFailed(c, fiber.StatusPreconditionFailed, "Unsupported Tus-Resumable version")
+	}
+
+	if c.Get("Upload-Defer-Length") != "" {
+		return tusFailed(c, fiber.StatusBadRequest, "Upload-Defer-Length is not supported")
+	}
+	length, err := strconv.ParseInt(c.Get("Upload-Length"), 10, 64)
+	if err != nil || length <= 0 {
+		return tusFailed(c, fiber.StatusBadRequest, "Invalid Upload-Length")
+	}
+
+	meta, err := parseTusMetadata(c.Get("Upload-Metadata"))
+	if err != nil {
+		return tusFailed(c, fiber.StatusBadRequest, "Invalid Upload-Metadata")
+	}
+
+	category, ok := s.categories[meta["kategori"]]
+	if !ok {
+		return tusFailed(c, fiber.StatusBadRequest, "Upload-Metadata kategori must be foto or sertifikat")
+	}
+	declaredType := utils.NormalizeContentType(firstNonEmpty(meta["filetype"], meta["type"]))
+	if err := category.checkType(declaredType); err != nil {
+		return uploadFailed(c, err)
+	}
+	if length > category.tusMaxSize {
+		return tusFailed(c, fiber.StatusRequestEntityTooLarge, fmt.Sprintf("File too large (max %d MB)", category.tusMaxSize/(1024*1024)))
+	}
+
+	targetUserID, err := resolveUploadOwner(c, meta["user_id"])
+	if err != nil {
+		return uploadFailed(c, err)
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	if err := s.checkQuota(ctx, targetUserID, length); err != nil {
+		return uploadFailed(c, err)
+	}
+
+	s.cleanupExpiredTus(ctx)
+
+	userID, _ := c.Locals("user_id").(string)
+	upload := &models.TusUpload{
+		UserID:    targetUserID,
+		CreatedBy: userID,
+		Kategori:  category.name,
+		FileName:  firstNonEmpty(meta["filename"], meta["name"], "upload"+utils.ExtensionForContentType(declaredType)),
+		FileType:  declaredType,
+		Length:    length,
+		ExpiresAt: time.Now().Add(tusUploadExpiry),
+	}
+	if err := s.tusRepo.Create(ctx, upload); err != nil {
+		return tusFailed(c, fiber.StatusInternalServerError, "Failed to create upload")
+	}
+
+	if err := os.MkdirAll(s.tusDir, 0o755); err == nil {
+		var f *os.File
+		if f, err = os.Create(s.tusPath(upload)); err == nil {
+			err = f.Close()
+		}
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal membuat file sementara tus", "upload_id", upload.ID.Hex(), "err", err)
+		s.tusRepo.Delete(ctx, upload.ID)
+		return tusFailed(c, fiber.StatusInternalServerError, "Failed to create upload")
+	}
+
+	c.Set("Tus-Resumable", models.TusVersion)
+	c.Set(fiber.HeaderLocation, c.BaseURL()+"/files/tus/"+upload.ID.Hex())
+	c.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(time.RFC1123))
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// @Summary Status upload resumable (tus)
+// @Description Mengembalikan offset upload saat ini. Setelah selesai, header X-File-Id berisi ID file yang tersimpan
+// @Tags Files
+// @Param id path string true "Upload ID"
+// @Param Tus-Resumable header string true "Versi protokol, 1.0.0"
+// @Success 200 "Header Upload-Offset dan Upload-Length"
+// @Failure 404 "Upload tidak ditemukan"
+// @Failure 410 "Upload sudah kedaluwarsa"
+// @Security Bearer
+// @Router /files/tus/{id} [head]
+func (s *fileService) TusHead(c *fiber.Ctx) error {
+	if !tusVersionSupported(c) {
+		return tusFailed(c, fiber.StatusPreconditionFailed, "Unsupported Tus-Resumable version")
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Read)
+	defer cancel()
+
+	upload, status, msg := s.getTusUpload(ctx, c)
+	if upload == nil {
+		return tusFailed(c, status, msg)
+	}
+
+	setTusUploadHeaders(c, upload)
+	c.Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// @Summary Kirim potongan upload resumable (tus)
+// @Description Menambahkan potongan file mulai dari Upload-Offset. Maksimal ukuran satu potongan mengikuti batas body server (4 MB). Saat potongan terakhir diterima, file divalidasi dan disimpan seperti upload biasa; header X-File-Id berisi ID file. PATCH kosong pada offset terakhir mengulang pemrosesan yang gagal
+// @Tags Files
+// @Accept octet-stream
+// @Param id path string true "Upload ID"
+// @Param Tus-Resumable header string true "Versi protokol, 1.0.0"
+// @Param Upload-Offset header int true "Offset potongan ini"
+// @Success 204 "Header Upload-Offset berisi offset baru"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 "Upload tidak ditemukan"
+// @Failure 409 {object} map[string]interface{}
+// @Failure 410 "Upload sudah kedaluwarsa"
+// @Failure 413 {object} map[string]interface{}
+// @Failure 415 {object} map[string]interface{}
+// @Security Bearer
+// @Router /files/tus/{id} [patch]
+func (s *fileService) TusPatch(c *fiber.Ctx) error {
+	if !tusVersionSupported(c) {
+		return tusFailed(c, fiber.StatusPreconditionFailed, "Unsupported Tus-Resumable version")
+	}
+	if c.Get(fiber.HeaderContentType) != "application/offset+octet-stream" {
+		return tusFailed(c, fiber.StatusUnsupportedMediaType, "Content-Type must be application/offset+octet-stream")
+	}
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return tusFailed(c, fiber.StatusBadRequest, "Invalid Upload-Offset")
+	}
+
+	// Satu PATCH per upload dalam satu waktu
+	id := strings.Clone(c.Params("id"))
+	s.tusLocks.Lock(id)
+	defer s.tusLocks.Unlock(id)
+
+	ctx, cancel := requestContext(c, s.timeouts.Upload)
+	defer cancel()
+
+	upload, status, msg := s.getTusUpload(ctx, c)
+	if upload == nil {
+		return tusFailed(c, status, msg)
+	}
+	if upload.FileID != nil {
+		setTusUploadHeaders(c, upload)
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+	if offset != upload.Offset {
+		c.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+		return tusFailed(c, fiber.StatusConflict, "Upload-Offset does not match current offset")
+	}
+
+	chunk := c.Body()
+	newOffset := offset + int64(len(chunk))
+	if newOffset > upload.Length {
+		return tusFailed(c, fiber.StatusRequestEntityTooLarge, "Chunk exceeds Upload-Length")
+	}
+
+	if len(chunk) > 0 {
+		if err := writeTusChunk(s.tusPath(upload), offset, chunk); err != nil {
+			slog.ErrorContext(ctx, "Gagal menulis potongan tus", "upload_id", upload.ID.Hex(), "err", err)
+			return tusFailed(c, fiber.StatusInternalServerError, "Failed to write chunk")
+		}
+		advanced, err := s.tusRepo.AdvanceOffset(ctx, upload.ID, offset, newOffset)
+		if err != nil {
+			return tusFailed(c, fiber.StatusInternalServerError, "Failed to update upload")
+		}
+		if !advanced {
+			return tusFailed(c, fiber.StatusConflict, "Upload-Offset does not match current offset")
+		}
+		upload.Offset = newOffset
+	}
+
+	if upload.Offset == upload.Length {
+		if err := s.finishTusUpload(ctx, c, upload); err != nil {
+			setTusUploadHeaders(c, upload)
+			return uploadFailed(c, err)
+		}
+	}
+
+	setTusUploadHeaders(c, upload)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Batalkan upload resumable (tus)
+// @Description Menghapus upload tus beserta potongan yang sudah dikirim. File yang sudah selesai diproses tidak ikut dihapus
+// @Tags Files
+// @Param id path string true "Upload ID"
+// @Param Tus-Resumable header string true "Versi protokol, 1.0.0"
+// @Success 204 "Upload dihapus"
+// @Failure 404 "Upload tidak ditemukan"
+// @Security Bearer
+// @Router /files/tus/{id} [delete]
+func (s *fileService) TusDelete(c *fiber.Ctx) error {
+	if !tusVersionSupported(c) {
+		return tusFailed(c, fiber.StatusPreconditionFailed, "Unsupported Tus-Resumable version")
+	}
+
+	ctx, cancel := requestContext(c, s.timeouts.Write)
+	defer cancel()
+
+	upload, status, msg := s.getTusUpload(ctx, c)
+	if upload == nil {
+		return tusFailed(c, status, msg)
+	}
+	if err := s.removeTusUpload(ctx, upload); err != nil {
+		return tusFailed(c, fiber.StatusInternalServerError, "Failed to delete upload")
+	}
+
+	c.Set("Tus-Resumable", models.TusVersion)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// finishTusUpload memproses upload yang sudah lengkap lewat jalur yang sama dengan upload
+// multipart. Upload yang ditolak validasi dihapus karena tidak mungkin berhasil jika diulang.
+func (s *fileService) finishTusUpload(ctx context.Context, c *fiber.Ctx, upload *models.TusUpload) error {
+	f, err := os.Open(s.tusPath(upload))
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal membuka file sementara tus", "upload_id", upload.ID.Hex(), "err", err)
+		return newUploadError(fiber.StatusInternalServerError, "Failed to read uploaded file")
+	}
+	defer f.Close()
+
+	fileModel, err := s.storeUpload(c, f, upload.Length, upload.FileType, upload.FileName, s.categories[upload.Kategori], upload.UserID)
+	if err != nil {
+		var uerr *uploadError
+		if errors.As(err, &uerr) && uerr.status < fiber.StatusInternalServerError {
+			s.removeTusUpload(ctx, upload)
+		}
+		return err
+	}
+
+	if err := s.tusRepo.SetFileID(ctx, upload.ID, fileModel.ID); err != nil {
+		slog.ErrorContext(ctx, "Gagal mencatat file hasil upload tus", "upload_id", upload.ID.Hex(), "err", err)
+	}
+	upload.FileID = &fileModel.ID
+	if err := os.Remove(s.tusPath(upload)); err != nil {
+		slog.WarnContext(ctx, "Gagal menghapus file sementara tus", "upload_id", upload.ID.Hex(), "err", err)
+	}
+	return nil
+}
+
+// getTusUpload mengambil upload dari parameter :id. Hanya user yang membuat upload
+// yang bisa melihat dan melanjutkannya; upload kedaluwarsa dihapus. Jika gagal,
+// status dan pesan error dikembalikan.
+func (s *fileService) getTusUpload(ctx context.Context, c *fiber.Ctx) (*models.TusUpload, int, string) {
+	upload, err := s.tusRepo.GetByID(ctx, c.Params("id"))
+	if err != nil {
+		return nil, fiber.StatusInternalServerError, "Failed to get upload"
+	}
+	userID, _ := c.Locals("user_id").(string)
+	if upload == nil || upload.CreatedBy != userID {
+		return nil, fiber.StatusNotFound, "Upload not found"
+	}
+	if upload.FileID == nil && time.Now().After(upload.ExpiresAt) {
+		s.removeTusUpload(ctx, upload)
+		return nil, fiber.StatusGone, "Upload expired"
+	}
+	return upload, 0, ""
+}
+
+func (s *fileService) removeTusUpload(ctx context.Context, upload *models.TusUpload) error {
+	if err := os.Remove(s.tusPath(upload)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.WarnContext(ctx, "Gagal menghapus file sementara tus", "upload_id", upload.ID.Hex(), "err", err)
+	}
+	return s.tusRepo.Delete(ctx, upload.ID)
+}
+
+// cleanupExpiredTus menghapus upload kedaluwarsa beserta file sementaranya
+func (s *fileService) cleanupExpiredTus(ctx context.Context) {
+	expired, err := s.tusRepo.GetExpired(ctx, time.Now())
+	if err != nil {
+		slog.WarnContext(ctx, "Gagal mengambil upload tus kedaluwarsa", "err", err)
+		return
+	}
+	for i := range expired {
+		if err := s.removeTusUpload(ctx, &expired[i]); err != nil {
+			slog.WarnContext(ctx, "Gagal menghapus upload tus", "upload_id", expired[i].ID.Hex(), "err", err)
+		}
+	}
+}
+
+func (s *fileService) tusPath(upload *models.TusUpload) string {
+	return filepath.Join(s.tusDir, upload.ID.Hex())
+}
+
+// writeTusChunk menulis potongan mulai dari offset. Sisa tulisan PATCH sebelumnya yang
+// gagal (melewati offset tercatat) dibuang lebih dulu.
+func writeTusChunk(path string, offset int64, chunk []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(offset); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.WriteAt(chunk, offset); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func setTusUploadHeaders(c *fiber.Ctx, upload *models.TusUpload) {
+	c.Set("Tus-Resumable", models.TusVersion)
+	c.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	if upload.FileID != nil {
+		c.Set("X-File-Id", upload.FileID.Hex())
+	} else {
+		c.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(time.RFC1123))
+	}
+}
+
+// tusVersionSupported mengecek header Tus-Resumable; versi lain dijawab 412 dengan Tus-Version
+func tusVersionSupported(c *fiber.Ctx) bool {
+	if c.Get("Tus-Resumable") == models.TusVersion {
+		return true
+	}
+	c.Set("Tus-Version", models.TusVersion)
+	return false
+}
+
+// tusFailed mengirim error dengan format response upload, plus header Tus-Resumable
+func tusFailed(c *fiber.Ctx, status int, message string) error {
+	c.Set("Tus-Resumable", models.TusVersion)
+	return uploadFailed(c, newUploadError(status, message))
+}
+
+// parseTusMetadata mem-parsing header Upload-Metadata: pasangan "key base64value" dipisah koma
+func parseTusMetadata(header string) (map[string]string, error) {
+	meta := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, err
+		}
+		meta[key] = string(value)
+	}
+	return meta, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// tusLockMap menyimpan mutex per upload tus. Entri hanya ada selama ada PATCH yang memegang
+// atau menunggu kuncinya, sehingga upload yang ditinggalkan tidak menumpuk di memori.
+type tusLockMap struct {
+	mu    sync.Mutex
+	locks map[string]*tusLock
+}
+
+type tusLock struct {
+	sync.Mutex
+	refs int
+}
+
+func (l *tusLockMap) Lock(id string) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*tusLock{}
+	}
+	lock := l.locks[id]
+	if lock == nil {
+		lock = &tusLock{}
+		l.locks[id] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	lock.Lock()
+}
+
+func (l *tusLockMap) Unlock(id string) {
+	l.mu.Lock()
+	lock := l.locks[id]
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, id)
+	}
+	l.mu.Unlock()
+
+	lock.Unlock()
+}
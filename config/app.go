@@ -8,8 +8,9 @@ func NewApp() *fiber.App {
 	return fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			return c.Status(500).JSON(fiber.Map{
-				"success": false,
-				"error":   err.Error(),
+				"success":    false,
+				"error":      err.Error(),
+				"request_id": c.Locals("request_id"),
 			})
 		},
 	})
@@ -1,15 +1,16 @@
 package config
 
 import (
-	"log"
+	"log/slog"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
 
 func LoadEnv() {
 	if err := godotenv.Load(); err != nil {
-		log.Println(".env file not found, using system environment")
+		slog.Info(".env file not found, using system environment")
 	}
 }
 
@@ -19,3 +20,12 @@ func GetEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// GetEnvInt membaca env sebagai bilangan bulat; nilai kosong atau tidak valid memakai fallback
+func GetEnvInt(key string, fallback int64) int64 {
+	value, err := strconv.ParseInt(GetEnv(key, ""), 10, 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
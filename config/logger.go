@@ -1,12 +1,103 @@
 package config
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"os"
+	"strings"
 )
 
-var Logger *log.Logger
+// Logger adalah logger JSON aplikasi, juga dipasang sebagai slog.Default()
+var Logger *slog.Logger
 
+// InitLogger membuat logger JSON ke stdout dengan level dari LOG_LEVEL (debug, info, warn, error).
+// Paket log bawaan ikut diarahkan ke logger ini.
 func InitLogger() {
-	Logger = log.New(os.Stdout, "[CRUD-APP] ", log.Ldate|log.Ltime|log.Lshortfile)
+	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level:       parseLogLevel(GetEnv("LOG_LEVEL", "info")),
+		ReplaceAttr: redactAttr,
+	})
+	Logger = slog.New(contextHandler{handler})
+	slog.SetDefault(Logger)
+}
+
+// Fatal mencatat error lalu menghentikan aplikasi. Dipakai untuk kegagalan saat startup.
+func Fatal(msg string, args ...any) {
+	Logger.Error(msg, args...)
+	os.Exit(1)
+}
+
+func parseLogLevel(value string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Atribut log yang berisi data pribadi atau rahasia, nilainya tidak pernah ditulis ke log
+var redactedLogKeys = map[string]bool{
+	"password":      true,
+	"token":         true,
+	"authorization": true,
+	"signature":     true,
+	"username":      true,
+	"email":         true,
+	"no_telepon":    true,
+	"alamat":        true,
+	"nama":          true,
+	"nim":           true,
+	"search":        true,
+	"q":             true,
+}
+
+// RedactedLogKey mengecek apakah nilai dengan nama key harus disamarkan di log
+func RedactedLogKey(key string) bool {
+	return redactedLogKeys[strings.ToLower(key)]
+}
+
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if RedactedLogKey(a.Key) {
+		return slog.String(a.Key, "[REDACTED]")
+	}
+	return a
+}
+
+type logAttrsKey struct{}
+
+// WithLogAttrs menambahkan atribut yang ikut ditulis di setiap log yang memakai context ini,
+// mis. request_id dan user_id dari middleware
+func WithLogAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(logAttrsKey{}).([]slog.Attr)
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+	return context.WithValue(ctx, logAttrsKey{}, merged)
+}
+
+// contextHandler menambahkan atribut dari WithLogAttrs ke setiap record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if attrs, ok := ctx.Value(logAttrsKey{}).([]slog.Attr); ok {
+			r.AddAttrs(attrs...)
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
 }
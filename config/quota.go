@@ -0,0 +1,44 @@
+package config
+
+import "strings"
+
+// QuotaLimit adalah batas penyimpanan file satu user. Nilai 0 berarti tanpa batas.
+type QuotaLimit struct {
+	MaxBytes int64
+	MaxFiles int64
+}
+
+// StorageQuotas berisi kuota bawaan per role, bisa ditimpa per user lewat /files/quota
+type StorageQuotas struct {
+	ByRole  map[string]QuotaLimit
+	Default QuotaLimit // untuk role yang tidak terdaftar
+}
+
+// LoadStorageQuotas membaca kuota per role dari env QUOTA_<ROLE>_MB dan QUOTA_<ROLE>_FILES
+func LoadStorageQuotas() StorageQuotas {
+	load := func(role string, mb, files int64) QuotaLimit {
+		prefix := "QUOTA_" + strings.ToUpper(role)
+		return QuotaLimit{
+			MaxBytes: GetEnvInt(prefix+"_MB", mb) * 1024 * 1024,
+			MaxFiles: GetEnvInt(prefix+"_FILES", files),
+		}
+	}
+
+	user := load("user", 50, 100)
+	return StorageQuotas{
+		ByRole: map[string]QuotaLimit{
+			"user":   user,
+			"alumni": load("alumni", 50, 100),
+			"admin":  load("admin", 0, 0),
+		},
+		Default: user,
+	}
+}
+
+// ForRole mengembalikan kuota bawaan untuk role
+func (q StorageQuotas) ForRole(role string) QuotaLimit {
+	if limit, ok := q.ByRole[role]; ok {
+		return limit
+	}
+	return q.Default
+}
@@ -0,0 +1,33 @@
+package config
+
+import "time"
+
+// Timeouts adalah batas waktu operasi per jenis, dibaca dari env dalam detik
+type Timeouts struct {
+	Request time.Duration // seluruh request HTTP (REQUEST_TIMEOUT_SECONDS)
+	Read    time.Duration // query baca sederhana (DB_READ_TIMEOUT_SECONDS)
+	Write   time.Duration // insert / update / delete (DB_WRITE_TIMEOUT_SECONDS)
+	Report  time.Duration // agregasi statistik dan laporan (DB_REPORT_TIMEOUT_SECONDS)
+	Upload  time.Duration // upload, unduh dan ekspor file (UPLOAD_TIMEOUT_SECONDS)
+	Batch   time.Duration // pekerjaan admin massal: import, rekonsiliasi (BATCH_TIMEOUT_SECONDS)
+}
+
+// LoadTimeouts membaca batas waktu dari env
+func LoadTimeouts() Timeouts {
+	seconds := func(key string, fallback int64) time.Duration {
+		value := GetEnvInt(key, fallback)
+		if value <= 0 {
+			value = fallback
+		}
+		return time.Duration(value) * time.Second
+	}
+
+	return Timeouts{
+		Request: seconds("REQUEST_TIMEOUT_SECONDS", 15*60),
+		Read:    seconds("DB_READ_TIMEOUT_SECONDS", 5),
+		Write:   seconds("DB_WRITE_TIMEOUT_SECONDS", 10),
+		Report:  seconds("DB_REPORT_TIMEOUT_SECONDS", 30),
+		Upload:  seconds("UPLOAD_TIMEOUT_SECONDS", 60),
+		Batch:   seconds("BATCH_TIMEOUT_SECONDS", 10*60),
+	}
+}
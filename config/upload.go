@@ -0,0 +1,85 @@
+package config
+
+import (
+	"crypto/rand"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// UploadLimits adalah batas ukuran file per kategori dalam MB. Upload multipart dikirim
+// dalam satu request sehingga batasnya lebih kecil dari upload resumable (tus).
+type UploadLimits struct {
+	FotoMB          int64
+	SertifikatMB    int64
+	TusFotoMB       int64
+	TusSertifikatMB int64
+}
+
+// LoadUploadLimits membaca batas ukuran upload dari env
+func LoadUploadLimits() UploadLimits {
+	return UploadLimits{
+		FotoMB:          GetEnvInt("UPLOAD_MAX_FOTO_MB", 1),
+		SertifikatMB:    GetEnvInt("UPLOAD_MAX_SERTIFIKAT_MB", 2),
+		TusFotoMB:       GetEnvInt("TUS_MAX_FOTO_MB", 5),
+		TusSertifikatMB: GetEnvInt("TUS_MAX_SERTIFIKAT_MB", 20),
+	}
+}
+
+// TusUploadDir adalah folder sementara untuk potongan upload tus yang belum selesai
+func TusUploadDir() string {
+	return GetEnv("TUS_UPLOAD_DIR", filepath.Join(os.TempDir(), "crud-app-tus"))
+}
+
+// Panjang minimal FILE_LINK_SECRET dalam byte
+const minFileLinkSecretLen = 32
+
+// Nilai contoh yang pernah ada di dokumentasi/.env dan tidak boleh dipakai sebagai kunci
+var placeholderFileLinkSecrets = map[string]bool{
+	"ganti_dengan_kunci_acak_yang_panjang": true,
+	"changeme":                             true,
+	"secret":                               true,
+}
+
+// FileLinkSecret adalah kunci HMAC untuk link unduh bertanda tangan (FILE_LINK_SECRET).
+// Jika tidak diisi dipakai kunci acak, sehingga link lama tidak berlaku setelah restart.
+// Aplikasi berhenti jika kunci berupa nilai contoh atau lebih pendek dari 32 byte.
+func FileLinkSecret() []byte {
+	if secret := GetEnv("FILE_LINK_SECRET", ""); secret != "" {
+		if placeholderFileLinkSecrets[secret] {
+			Fatal("FILE_LINK_SECRET masih berisi nilai contoh, isi dengan kunci acak minimal 32 byte")
+		}
+		if len(secret) < minFileLinkSecretLen {
+			Fatal("FILE_LINK_SECRET terlalu pendek, minimal 32 byte", "length", len(secret))
+		}
+		return []byte(secret)
+	}
+	slog.Warn("FILE_LINK_SECRET tidak diisi, signed link memakai kunci acak dan tidak berlaku setelah restart")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		Fatal("Gagal membuat kunci signed link", "err", err)
+	}
+	return secret
+}
+
+// UploadConfig mengumpulkan pengaturan upload file
+type UploadConfig struct {
+	Limits     UploadLimits
+	Quotas     StorageQuotas
+	TusDir     string
+	LinkSecret []byte
+	// Jumlah versi yang disimpan per dokumen (DOKUMEN_MAX_VERSIONS), 0 berarti tanpa batas
+	DokumenMaxVersions int
+}
+
+// LoadUploadConfig membaca seluruh pengaturan upload dari env
+func LoadUploadConfig() UploadConfig {
+	return UploadConfig{
+		Limits:     LoadUploadLimits(),
+		Quotas:     LoadStorageQuotas(),
+		TusDir:     TusUploadDir(),
+		LinkSecret: FileLinkSecret(),
+
+		DokumenMaxVersions: int(GetEnvInt("DOKUMEN_MAX_VERSIONS", 5)),
+	}
+}
@@ -2,11 +2,15 @@ package database
 
 import (
 	"context"
-	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"time"
 
+	"crud-app/config"
+	"crud-app/metrics"
+	"crud-app/tracing"
+
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -17,24 +21,47 @@ func ConnectMongo() *mongo.Database {
 	mongoURI := os.Getenv("MONGO_URI")
 	if mongoURI == "" {
 		mongoURI = "mongodb://localhost:27017"
-		log.Println("MONGO_URI tidak disetel. Menggunakan default:", mongoURI)
+		slog.Warn("MONGO_URI tidak disetel, memakai default", "uri", mongoURI)
 	}
 
-	clientOptions := options.Client().ApplyURI(mongoURI)
+	clientOptions := options.Client().ApplyURI(mongoURI).
+		SetMonitor(combineMonitors(metrics.NewMongoMonitor(), tracing.NewMongoMonitor()))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
-		log.Fatalf("Gagal konek ke MongoDB: %v", err)
+		config.Fatal("Gagal konek ke MongoDB", "err", err)
 	}
 
 	if err := client.Ping(ctx, nil); err != nil {
-		log.Fatalf("Gagal ping MongoDB: %v", err)
+		config.Fatal("Gagal ping MongoDB", "err", err)
 	}
 
-	fmt.Println("🎉 Berhasil terhubung ke MongoDB!")
+	slog.Info("Berhasil terhubung ke MongoDB")
 	DB = client.Database("alumnidb")
 	return DB
 }
+
+// combineMonitors meneruskan setiap event command ke beberapa monitor, karena client
+// MongoDB hanya menerima satu CommandMonitor
+func combineMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				m.Started(ctx, evt)
+			}
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				m.Succeeded(ctx, evt)
+			}
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				m.Failed(ctx, evt)
+			}
+		},
+	}
+}
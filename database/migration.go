@@ -0,0 +1,232 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"crud-app/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migration adalah perubahan data satu kali yang dijalankan saat startup
+type Migration struct {
+	ID          string
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// Daftar migration, dijalankan berurutan dan hanya sekali (dicatat di collection "migrations")
+var migrations = []Migration{
+	{
+		ID:          "20261019_normalize_pekerjaan_tanggal_status",
+		Description: "Ubah tanggal pekerjaan menjadi date dan seragamkan status_pekerjaan",
+		Up:          normalizePekerjaanTanggalStatus,
+	},
+	{
+		ID:          "20261019_parse_pekerjaan_gaji_range",
+		Description: "Ubah gaji_range teks bebas menjadi gaji_min/gaji_max/gaji_mata_uang/gaji_periode",
+		Up:          parsePekerjaanGajiRange,
+	},
+	{
+		ID:          "20261019_create_companies_indexes",
+		Description: "Index pencarian nama / alias perusahaan dan perusahaan_id pada pekerjaan",
+		Up:          createCompaniesIndexes,
+	},
+	{
+		ID:          "20261019_seed_industri_kbli",
+		Description: "Isi taksonomi industri dari KBLI (kategori dan golongan pokok)",
+		Up:          seedIndustriKBLI,
+	},
+	{
+		ID:          "20261019_create_pengajuan_pekerjaan_indexes",
+		Description: "Index antrian review dan daftar pengajuan per alumni",
+		Up:          createPengajuanPekerjaanIndexes,
+	},
+	{
+		ID:          "20261019_create_audit_log_indexes",
+		Description: "Index audit log per waktu dan per entitas",
+		Up:          createAuditLogIndexes,
+	},
+	{
+		ID:          "20261019_files_storage_backend",
+		Description: "Catat backend dan key storage untuk file lama di disk (ganti file_path)",
+		Up:          backfillFilesStorage,
+	},
+	{
+		ID:          "20261019_create_file_reference_indexes",
+		Description: "Index referensi file pada foto profil alumni dan lampiran pekerjaan",
+		Up:          createFileReferenceIndexes,
+	},
+	{
+		ID:          "20261019_create_tus_uploads_indexes",
+		Description: "Index masa berlaku upload resumable (tus) untuk pembersihan",
+		Up:          createTusUploadsIndexes,
+	},
+	{
+		ID:          "20261019_files_kategori",
+		Description: "Isi kategori file lama (foto / sertifikat) dan index pemakaian storage per user",
+		Up:          backfillFilesKategori,
+	},
+	{
+		ID:          "20261019_create_files_sha256_index",
+		Description: "Index hash isi file untuk deduplikasi upload",
+		Up:          createFilesSHA256Index,
+	},
+	{
+		ID:          "20261019_create_dokumen_indexes",
+		Description: "Index dokumen per user (jenis + nama unik) dan referensi file versinya",
+		Up:          createDokumenIndexes,
+	},
+}
+
+// RunMigrations menjalankan semua migration yang belum pernah dijalankan
+func RunMigrations(db *mongo.Database) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	applied := db.Collection("migrations")
+
+	for _, m := range migrations {
+		count, err := applied.CountDocuments(ctx, bson.M{"_id": m.ID})
+		if err != nil {
+			config.Fatal("Gagal membaca status migration", "migration", m.ID, "err", err)
+		}
+		if count > 0 {
+			continue
+		}
+
+		slog.Info("Menjalankan migration", "migration", m.ID, "description", m.Description)
+		if err := m.Up(ctx, db); err != nil {
+			config.Fatal("Migration gagal", "migration", m.ID, "err", err)
+		}
+
+		if _, err := applied.InsertOne(ctx, bson.M{
+			"_id":         m.ID,
+			"description": m.Description,
+			"applied_at":  time.Now(),
+		}); err != nil {
+			config.Fatal("Gagal mencatat migration", "migration", m.ID, "err", err)
+		}
+	}
+}
+
+// createCompaniesIndexes membuat index untuk pencocokan nama perusahaan
+func createCompaniesIndexes(ctx context.Context, db *mongo.Database) error {
+	if _, err := db.Collection("companies").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "nama_normal", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "alias_normal", Value: 1}}},
+	}); err != nil {
+		return err
+	}
+
+	_, err := db.Collection("pekerjaan_alumni").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "perusahaan_id", Value: 1}},
+	})
+	return err
+}
+
+// createPengajuanPekerjaanIndexes membuat index untuk antrian review pengajuan pekerjaan
+func createPengajuanPekerjaanIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("pengajuan_pekerjaan").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "alumni_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "pekerjaan_id", Value: 1}, {Key: "status", Value: 1}}},
+	})
+	return err
+}
+
+// createAuditLogIndexes membuat index untuk penelusuran audit log
+func createAuditLogIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("audit_log").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "entitas", Value: 1}, {Key: "entitas_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "aksi", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	return err
+}
+
+// createFileReferenceIndexes membuat index untuk melepas referensi saat file dihapus
+func createFileReferenceIndexes(ctx context.Context, db *mongo.Database) error {
+	if _, err := db.Collection("alumni").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "foto_file_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}); err != nil {
+		return err
+	}
+	for _, name := range []string{"pekerjaan_alumni", "trash_pekerjaan"} {
+		if _, err := db.Collection(name).Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "lampiran.file_id", Value: 1}},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTusUploadsIndexes membuat index untuk mencari upload tus yang kedaluwarsa. Bukan
+// index TTL karena file sementaranya juga harus dihapus oleh aplikasi.
+func createTusUploadsIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("tus_uploads").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "expires_at", Value: 1}},
+	})
+	return err
+}
+
+// backfillFilesKategori mengisi kategori file lama dari tipe file-nya, lalu membuat index
+// untuk menghitung pemakaian storage per user
+func backfillFilesKategori(ctx context.Context, db *mongo.Database) error {
+	files := db.Collection("files")
+	if _, err := files.UpdateMany(ctx,
+		bson.M{"kategori": bson.M{"$exists": false}, "file_type": bson.M{"$regex": "^image/"}},
+		bson.M{"$set": bson.M{"kategori": "foto"}},
+	); err != nil {
+		return err
+	}
+	if _, err := files.UpdateMany(ctx,
+		bson.M{"kategori": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"kategori": "sertifikat"}},
+	); err != nil {
+		return err
+	}
+
+	_, err := files.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "kategori", Value: 1}},
+	})
+	return err
+}
+
+// createFilesSHA256Index membuat index hash isi file. File lama tanpa hash tetap
+// memakai objeknya sendiri dan tidak ikut dideduplikasi.
+func createFilesSHA256Index(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("files").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "sha256", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	})
+	return err
+}
+
+func createDokumenIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("dokumen").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "jenis", Value: 1}, {Key: "nama", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "versions.file_id", Value: 1}}},
+		{Keys: bson.D{{Key: "current_file_id", Value: 1}}},
+	})
+	return err
+}
+
+// backfillFilesStorage menandai file lama sebagai milik backend local. File lama
+// tersimpan langsung di ./uploads, sehingga key-nya adalah file_name.
+func backfillFilesStorage(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("files").UpdateMany(ctx,
+		bson.M{"storage_key": bson.M{"$exists": false}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{"storage_backend": "local", "storage_key": "$file_name"}}},
+			{{Key: "$unset", Value: "file_path"}},
+		},
+	)
+	return err
+}
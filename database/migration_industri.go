@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Data KBLI 2020 level kategori dan golongan pokok beserta kata kunci pemetaan
+//
+//go:embed seed/kbli.json
+var kbliSeed []byte
+
+type kbliSeedEntry struct {
+	Kode      string   `json:"kode"`
+	Induk     string   `json:"induk"`
+	Judul     string   `json:"judul"`
+	KataKunci []string `json:"kata_kunci"`
+}
+
+// seedIndustriKBLI mengisi collection "industri" dari seed/kbli.json (upsert per kode)
+func seedIndustriKBLI(ctx context.Context, db *mongo.Database) error {
+	var entries []kbliSeedEntry
+	if err := json.Unmarshal(kbliSeed, &entries); err != nil {
+		return fmt.Errorf("seed KBLI tidak valid: %w", err)
+	}
+
+	paths := make(map[string][]string, len(entries))
+	var writes []mongo.WriteModel
+	now := time.Now()
+	for _, e := range entries {
+		level := models.LevelIndustri(e.Kode)
+		if level == "" {
+			return fmt.Errorf("kode KBLI %q tidak valid", e.Kode)
+		}
+
+		path := []string{}
+		if e.Induk != "" {
+			parent, ok := paths[e.Induk]
+			if !ok {
+				return fmt.Errorf("induk %q untuk kode %s belum didefinisikan", e.Induk, e.Kode)
+			}
+			path = append(append(path, parent...), e.Induk)
+		}
+		paths[e.Kode] = path
+
+		kataKunci := e.KataKunci
+		if kataKunci == nil {
+			kataKunci = []string{}
+		}
+		doc := models.Industri{
+			Kode:      e.Kode,
+			Judul:     e.Judul,
+			Level:     level,
+			KodeInduk: e.Induk,
+			Path:      path,
+			KataKunci: kataKunci,
+			UpdatedAt: now,
+		}
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": e.Kode}).
+			SetReplacement(doc).
+			SetUpsert(true))
+	}
+
+	collection := db.Collection("industri")
+	if _, err := collection.BulkWrite(ctx, writes); err != nil {
+		return err
+	}
+
+	if _, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "kode_induk", Value: 1}}},
+		{Keys: bson.D{{Key: "kata_kunci", Value: 1}}},
+	}); err != nil {
+		return err
+	}
+
+	_, err := db.Collection("pekerjaan_alumni").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "kode_industri", Value: 1}},
+	})
+	return err
+}
@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	models "crud-app/app/model"
+	"crud-app/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Pemetaan status lama (free text) ke enum status_pekerjaan
+var legacyStatusPekerjaan = map[string]string{
+	"aktif":           models.StatusPekerjaanAktif,
+	"active":          models.StatusPekerjaanAktif,
+	"bekerja":         models.StatusPekerjaanAktif,
+	"masih bekerja":   models.StatusPekerjaanAktif,
+	"tetap":           models.StatusPekerjaanAktif,
+	"kontrak":         models.StatusPekerjaanAktif,
+	"cuti":            models.StatusPekerjaanCuti,
+	"leave":           models.StatusPekerjaanCuti,
+	"selesai":         models.StatusPekerjaanSelesai,
+	"resign":          models.StatusPekerjaanSelesai,
+	"keluar":          models.StatusPekerjaanSelesai,
+	"berhenti":        models.StatusPekerjaanSelesai,
+	"tidak aktif":     models.StatusPekerjaanSelesai,
+	"nonaktif":        models.StatusPekerjaanSelesai,
+	"inactive":        models.StatusPekerjaanSelesai,
+	"phk":             models.StatusPekerjaanSelesai,
+	"pensiun":         models.StatusPekerjaanSelesai,
+	"kontrak selesai": models.StatusPekerjaanSelesai,
+}
+
+// normalizePekerjaanTanggalStatus mengubah tanggal_mulai_kerja / tanggal_selesai_kerja
+// bertipe string menjadi date (tanpa jam) dan memetakan status_pekerjaan ke enum
+func normalizePekerjaanTanggalStatus(ctx context.Context, db *mongo.Database) error {
+	for _, name := range []string{"pekerjaan_alumni", "trash_pekerjaan"} {
+		collection := db.Collection(name)
+
+		cursor, err := collection.Find(ctx, bson.M{})
+		if err != nil {
+			return err
+		}
+
+		updated := 0
+		for cursor.Next(ctx) {
+			var doc bson.M
+			if err := cursor.Decode(&doc); err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+
+			set := bson.M{}
+			unset := bson.M{}
+
+			if t, ok := migrateDateValue(doc["tanggal_mulai_kerja"]); ok {
+				set["tanggal_mulai_kerja"] = t
+			} else if doc["tanggal_mulai_kerja"] != nil {
+				slog.Warn("tanggal_mulai_kerja tidak bisa dikonversi", "collection", name, "id", doc["_id"], "nilai", doc["tanggal_mulai_kerja"])
+			}
+
+			hasSelesai := false
+			switch v := doc["tanggal_selesai_kerja"].(type) {
+			case nil:
+			case string:
+				if strings.TrimSpace(v) == "" {
+					unset["tanggal_selesai_kerja"] = ""
+					break
+				}
+				if t, ok := migrateDateValue(v); ok {
+					set["tanggal_selesai_kerja"] = t
+					hasSelesai = true
+				} else {
+					slog.Warn("tanggal_selesai_kerja tidak bisa dikonversi", "collection", name, "id", doc["_id"], "nilai", v)
+				}
+			default:
+				if t, ok := migrateDateValue(v); ok {
+					set["tanggal_selesai_kerja"] = t
+					hasSelesai = true
+				}
+			}
+
+			statusLama, _ := doc["status_pekerjaan"].(string)
+			status, ok := legacyStatusPekerjaan[strings.ToLower(strings.TrimSpace(statusLama))]
+			if !ok {
+				// Status tidak dikenal: tentukan dari ada tidaknya tanggal selesai
+				status = models.StatusPekerjaanAktif
+				if hasSelesai {
+					status = models.StatusPekerjaanSelesai
+				}
+				slog.Info("status_pekerjaan dipetakan", "collection", name, "id", doc["_id"], "dari", statusLama, "ke", status)
+			}
+			if status != statusLama {
+				set["status_pekerjaan"] = status
+			}
+
+			update := bson.M{}
+			if len(set) > 0 {
+				update["$set"] = set
+			}
+			if len(unset) > 0 {
+				update["$unset"] = unset
+			}
+			if len(update) == 0 {
+				continue
+			}
+
+			if _, err := collection.UpdateOne(ctx, bson.M{"_id": doc["_id"]}, update); err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+			updated++
+		}
+		if err := cursor.Err(); err != nil {
+			cursor.Close(ctx)
+			return err
+		}
+		cursor.Close(ctx)
+
+		slog.Info("Dokumen pekerjaan dinormalisasi", "collection", name, "jumlah", updated)
+	}
+	return nil
+}
+
+// parsePekerjaanGajiRange mengisi field gaji terstruktur dari gaji_range lama.
+// gaji_range yang tidak bisa di-parse dibiarkan agar bisa diperbaiki manual.
+func parsePekerjaanGajiRange(ctx context.Context, db *mongo.Database) error {
+	for _, name := range []string{"pekerjaan_alumni", "trash_pekerjaan"} {
+		collection := db.Collection(name)
+
+		cursor, err := collection.Find(ctx, bson.M{"gaji_range": bson.M{"$type": "string"}})
+		if err != nil {
+			return err
+		}
+
+		var docs []bson.M
+		if err := cursor.All(ctx, &docs); err != nil {
+			return err
+		}
+
+		parsed, failed := 0, 0
+		for _, doc := range docs {
+			gajiRange, _ := doc["gaji_range"].(string)
+			gaji, err := utils.ParseSalaryRange(gajiRange)
+			if err != nil {
+				slog.Warn("gaji_range tidak bisa di-parse", "collection", name, "id", doc["_id"], "gaji_range", gajiRange, "err", err)
+				failed++
+				continue
+			}
+
+			update := bson.M{
+				"$set": bson.M{
+					"gaji_min":       gaji.GajiMin,
+					"gaji_max":       gaji.GajiMax,
+					"gaji_mata_uang": gaji.GajiMataUang,
+					"gaji_periode":   gaji.GajiPeriode,
+				},
+				"$unset": bson.M{"gaji_range": ""},
+			}
+			if _, err := collection.UpdateOne(ctx, bson.M{"_id": doc["_id"]}, update); err != nil {
+				return err
+			}
+			parsed++
+		}
+
+		slog.Info("Parsing gaji_range selesai", "collection", name, "berhasil", parsed, "gagal", failed)
+	}
+	return nil
+}
+
+// migrateDateValue mengubah nilai tanggal (string atau date) menjadi date tanpa jam
+func migrateDateValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		t, err := utils.ParseDate(v)
+		if err != nil {
+			return nil, false
+		}
+		return t, true
+	case primitive.DateTime:
+		return utils.TruncateDate(v.Time()), true
+	default:
+		return nil, false
+	}
+}
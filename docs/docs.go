@@ -132,42 +132,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/upload/foto": {
-            "post": {
+        "/files/dokumen": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Upload file foto dengan maksimal 1 MB",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Daftar dokumen beserta versi aktifnya. User melihat miliknya sendiri, admin melihat semua atau memilih user lewat user_id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Files"
+                    "Dokumen"
                 ],
-                "summary": "Upload foto (profile picture)",
+                "summary": "Daftar dokumen",
                 "parameters": [
-                    {
-                        "type": "file",
-                        "description": "Image file (JPEG/PNG)",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "description": "User ID (untuk admin upload)",
+                        "description": "User ID (khusus admin)",
                         "name": "user_id",
-                        "in": "formData"
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -180,13 +170,6 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "413": {
-                        "description": "Request Entity Too Large",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -195,39 +178,33 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/files/upload/sertifikat": {
+            },
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Upload file sertifikat dalam format PDF dengan maksimal 2 MB",
+                "description": "Membuat dokumen logis (foto_profil, ijazah, transkrip, sertifikat) yang menyimpan riwayat versi file. Nama opsional, untuk membedakan beberapa dokumen dengan jenis yang sama. Admin wajib mengisi user_id pemilik",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Files"
+                    "Dokumen"
                 ],
-                "summary": "Upload sertifikat (PDF)",
+                "summary": "Buat dokumen",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "PDF file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID (untuk admin upload)",
-                        "name": "user_id",
-                        "in": "formData"
+                        "description": "Dokumen",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateDokumenRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -245,8 +222,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "413": {
-                        "description": "Request Entity Too Large",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -262,25 +239,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/{id}": {
+        "/files/dokumen/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get detail file berdasarkan ID",
+                "description": "Detail dokumen beserta versi aktif dan seluruh riwayat versinya (admin semua dokumen, user hanya miliknya)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Files"
+                    "Dokumen"
                 ],
-                "summary": "Get file by ID",
+                "summary": "Detail dokumen",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "File ID",
+                        "description": "Dokumen ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -300,30 +277,51 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/files/dokumen/{id}/rollback": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Hapus file dari storage dan database",
+                "description": "Menjadikan versi sebelumnya sebagai versi aktif. Riwayat versi tidak berubah",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Files"
+                    "Dokumen"
                 ],
-                "summary": "Delete file",
+                "summary": "Rollback dokumen",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "File ID",
+                        "description": "Dokumen ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Versi tujuan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RollbackDokumenRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -334,6 +332,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -341,6 +346,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -351,65 +363,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/alumni": {
+        "/files/dokumen/{id}/versions": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Mengambil daftar alumni berdasarkan parameter pencarian, pengurutan, dan batas halaman dengan informasi meta",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Daftar versi dokumen dari yang terbaru, ditandai mana yang aktif",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Dokumen"
                 ],
-                "summary": "Mendapatkan daftar alumni dengan pencarian, sorting, dan pagination",
+                "summary": "Riwayat versi dokumen",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Nomor halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Kolom untuk sorting: nim, nama, jurusan, angkatan, tahun_lulus, email, created_at (default: nama)",
-                        "name": "sortBy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutan sorting: asc atau desc (default: asc)",
-                        "name": "order",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Kata kunci pencarian di semua field",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Dokumen ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response dengan data alumni dan meta informasi",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AlumniResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error response",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -423,7 +417,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Menambahkan data alumni ke dalam database (hanya admin)",
+                "description": "Menjadikan file yang sudah di-upload sebagai versi baru sekaligus versi aktif dokumen. File harus milik pemilik dokumen, kategorinya sesuai jenis dokumen dan sudah lolos pemindaian. Versi lama di luar batas DOKUMEN_MAX_VERSIONS dihapus beserta file-nya. Versi baru dokumen foto_profil otomatis menjadi foto profil alumni pemiliknya",
                 "consumes": [
                     "application/json"
                 ],
@@ -431,73 +425,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Dokumen"
                 ],
-                "summary": "Menambah data alumni baru",
+                "summary": "Tambah versi dokumen",
                 "parameters": [
                     {
-                        "description": "Data Alumni Baru (NIM, Nama, Jurusan, Email, TahunLulus)",
+                        "type": "string",
+                        "description": "Dokumen ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "File",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateAlumniRequest"
+                            "$ref": "#/definitions/models.TambahVersiDokumenRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "success response dengan data alumni baru",
+                        "description": "Created",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "request body tidak valid atau field kosong",
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "error response",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/unair/alumni/all": {
-            "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Mengambil seluruh data alumni dari database tanpa pagination",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Alumni"
-                ],
-                "summary": "Mendapatkan semua data alumni",
-                "responses": {
-                    "200": {
-                        "description": "success response dengan array data alumni",
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error response",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -506,34 +485,82 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/alumni/without-pekerjaan": {
+        "/files/export": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Mengambil daftar alumni yang belum memiliki pekerjaan dengan jumlah totalnya",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengunduh semua foto dan sertifikat milik sekumpulan alumni sebagai ZIP yang dibuat langsung dari storage. Alumni dipilih lewat alumni_id (dipisah koma) atau filter search / jurusan / angkatan / tahun_lulus (maks 500 alumni). File yang diikutkan adalah file milik akun user alumni, foto profil dan lampiran pekerjaannya, dengan aturan akses yang sama seperti daftar file: admin semua file, user lain hanya file miliknya. ZIP berisi manifest.csv yang memetakan file ke alumni dan nama aslinya",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Files"
+                ],
+                "summary": "Ekspor dokumen alumni (ZIP)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID alumni, dipisah koma",
+                        "name": "alumni_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari nim, nama, jurusan, email, no_telepon atau alamat",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hanya kategori file ini (foto atau sertifikat)",
+                        "name": "kategori",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Mendapatkan alumni tanpa pekerjaan",
                 "responses": {
                     "200": {
-                        "description": "success response dengan array data alumni tanpa pekerjaan",
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error response",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -542,14 +569,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/alumni/{id}": {
-            "get": {
+        "/files/quota/{user_id}": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Mengambil data alumni tertentu berdasarkan ID",
+                "description": "Menetapkan kuota khusus user yang menimpa kuota bawaan role-nya (QUOTA_\u003cROLE\u003e_MB / QUOTA_\u003cROLE\u003e_FILES). Nilai 0 berarti tanpa batas (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -557,101 +584,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Files"
                 ],
-                "summary": "Mendapatkan data alumni berdasarkan ID",
+                "summary": "Atur kuota storage user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Alumni (MongoDB ObjectID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "success response dengan data alumni",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "alumni tidak ditemukan",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "error response",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Mengubah data alumni berdasarkan ID (hanya admin)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Alumni"
-                ],
-                "summary": "Mengupdate data alumni",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID Alumni (MongoDB ObjectID)",
-                        "name": "id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data Alumni yang Diupdate (minimal NIM, Nama, Jurusan, Email, TahunLulus)",
+                        "description": "Kuota",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateAlumniRequest"
+                            "$ref": "#/definitions/models.SetStorageQuotaRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response dengan data alumni yang diupdate",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "request body tidak valid atau field kosong",
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "alumni tidak ditemukan",
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error response",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -665,86 +642,100 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Mengubah status alumni menjadi nonaktif tanpa menghapus data dari database",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Menghapus kuota khusus sehingga user kembali memakai kuota bawaan role-nya (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Files"
                 ],
-                "summary": "Menghapus alumni (soft delete)",
+                "summary": "Hapus kuota khusus user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Alumni (MongoDB ObjectID)",
-                        "name": "id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response dengan message",
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "alumni tidak ditemukan",
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error response",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/files/reconcile": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Mengembalikan alumni yang sebelumnya dihapus (soft delete) menjadi aktif kembali",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mencari objek di storage yang tidak punya dokumen files (objek yatim) dan dokumen files yang isinya tidak ada di storage. action=report hanya melaporkan; action=quarantine memindah objek yatim ke quarantine/ dan menandai file hilang (missing_at) sehingga tidak bisa diunduh; action=delete menghapus objek yatim dan metadata file yang hilang beserta referensinya (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Files"
                 ],
-                "summary": "Merestore alumni yang dihapus",
+                "summary": "Rekonsiliasi storage dan metadata file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Alumni (MongoDB ObjectID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "report (default), quarantine atau delete",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Umur minimal objek yatim dalam menit (default 60)",
+                        "name": "min_age",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response dengan message",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReconcileResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "error response",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -753,54 +744,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/pekerjaan-alumni": {
+        "/files/signed/{id}": {
             "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Get data pekerjaan dengan fitur pagination, search, dan sort",
+                "description": "Mengunduh file dengan URL dari /files/{id}/link tanpa Bearer token. Parameter size dan inline boleh ditambahkan ke URL seperti pada /files/{id}/download",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Pekerjaan_Alumni"
+                    "Files"
                 ],
-                "summary": "Get pekerjaan with pagination",
+                "summary": "Download file lewat signed link",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Waktu kedaluwarsa (unix)",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Search keyword",
-                        "name": "search",
+                        "description": "User yang terikat ke link",
+                        "name": "user_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "created_at",
-                        "description": "Sort by field",
-                        "name": "sortBy",
+                        "description": "Tanda tangan HMAC",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Tampilkan di browser (Content-Disposition inline)",
+                        "name": "inline",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "asc",
-                        "description": "Sort order",
-                        "name": "order",
+                        "description": "Ukuran thumbnail foto (small, medium, large)",
+                        "name": "size",
                         "in": "query"
                     }
                 ],
@@ -808,63 +799,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.PekerjaanResponse"
+                            "type": "file"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "206": {
+                        "description": "Partial content",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
                         }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Tambah data pekerjaan alumni baru (admin only)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan_Alumni"
-                ],
-                "summary": "Create new pekerjaan",
-                "parameters": [
-                    {
-                        "description": "Create Pekerjaan Request",
-                        "name": "pekerjaanRequest",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/models.CreatePekerjaanRequest"
+                            "type": "object",
+                            "additionalProperties": true
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "410": {
+                        "description": "Gone",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -873,122 +839,223 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/pekerjaan-alumni/alumni/{alumni_id}": {
-            "get": {
+        "/files/tus": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get data pekerjaan berdasarkan alumni ID (admin only)",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Membuat upload tus baru. Upload-Metadata wajib berisi kategori (foto atau sertifikat), filename dan filetype (base64); admin juga wajib mengisi user_id. Batas ukuran per kategori diatur lewat TUS_MAX_FOTO_MB dan TUS_MAX_SERTIFIKAT_MB",
                 "tags": [
-                    "Pekerjaan_Alumni"
+                    "Files"
                 ],
-                "summary": "Get pekerjaan by alumni ID",
+                "summary": "Mulai upload resumable (tus)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Alumni ID",
-                        "name": "alumni_id",
-                        "in": "path",
+                        "description": "Versi protokol, 1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ukuran file (byte)",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metadata tus, mis. kategori c2VydGlmaWthdA==,filename YS5wZGY=,filetype YXBwbGljYXRpb24vcGRm",
+                        "name": "Upload-Metadata",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                    "201": {
+                        "description": "Header Location berisi URL upload"
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "412": {
+                        "description": "Versi tus tidak didukung"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
+            },
+            "options": {
+                "description": "Discovery upload resumable (tus 1.0.0): versi, ekstensi yang didukung dan ukuran maksimal",
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Info server tus",
+                "responses": {
+                    "204": {
+                        "description": "Header Tus-Version, Tus-Extension dan Tus-Max-Size"
+                    }
+                }
             }
         },
-        "/unair/pekerjaan-alumni/trash": {
-            "get": {
+        "/files/tus/{id}": {
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get data pekerjaan yang telah dihapus (soft delete)",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Menghapus upload tus beserta potongan yang sudah dikirim. File yang sudah selesai diproses tidak ikut dihapus",
                 "tags": [
-                    "Pekerjaan_Alumni"
+                    "Files"
                 ],
-                "summary": "Get deleted pekerjaan (trash)",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                "summary": "Batalkan upload resumable (tus)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                    {
+                        "type": "string",
+                        "description": "Versi protokol, 1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Upload dihapus"
+                    },
+                    "404": {
+                        "description": "Upload tidak ditemukan"
                     }
                 }
-            }
-        },
-        "/unair/pekerjaan-alumni/trash/delete/{id}": {
-            "delete": {
+            },
+            "head": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Hapus permanen data pekerjaan dari database",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Mengembalikan offset upload saat ini. Setelah selesai, header X-File-Id berisi ID file yang tersimpan",
                 "tags": [
-                    "Pekerjaan_Alumni"
+                    "Files"
                 ],
-                "summary": "Hard delete pekerjaan permanently",
+                "summary": "Status upload resumable (tus)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pekerjaan ID",
+                        "description": "Upload ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versi protokol, 1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Header Upload-Offset dan Upload-Length"
+                    },
+                    "404": {
+                        "description": "Upload tidak ditemukan"
+                    },
+                    "410": {
+                        "description": "Upload sudah kedaluwarsa"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Menambahkan potongan file mulai dari Upload-Offset. Maksimal ukuran satu potongan mengikuti batas body server (4 MB). Saat potongan terakhir diterima, file divalidasi dan disimpan seperti upload biasa; header X-File-Id berisi ID file. PATCH kosong pada offset terakhir mengulang pemrosesan yang gagal",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Kirim potongan upload resumable (tus)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versi protokol, 1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset potongan ini",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Header Upload-Offset berisi offset baru"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Upload tidak ditemukan"
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "410": {
+                        "description": "Upload sudah kedaluwarsa"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -997,40 +1064,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/unair/pekerjaan-alumni/{id}": {
-            "get": {
+        "/files/upload/foto": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get detail data pekerjaan berdasarkan ID",
+                "description": "Upload file foto (JPEG/PNG) dengan maksimal 1 MB (UPLOAD_MAX_FOTO_MB). Tipe file dideteksi dari isinya dan harus sama dengan Content-Type yang dikirim. Foto di-encode ulang tanpa EXIF, diputar sesuai orientasi, dikecilkan ke maks 1600px dan dibuatkan thumbnail small/medium/large",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan_Alumni"
+                    "Files"
                 ],
-                "summary": "Get pekerjaan by ID",
+                "summary": "Upload foto (profile picture)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Pekerjaan ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "Image file (JPEG/PNG)",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (untuk admin upload)",
+                        "name": "user_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1044,45 +1134,44 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/files/upload/sertifikat": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update data pekerjaan (admin only)",
+                "description": "Upload file sertifikat dalam format PDF dengan maksimal 2 MB (UPLOAD_MAX_SERTIFIKAT_MB). Tipe file dideteksi dari isinya dan struktur PDF divalidasi. File dipindai malware dan dikarantina sampai hasilnya bersih; file terinfeksi ditolak (422). File lebih besar bisa di-upload lewat /files/tus/",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan_Alumni"
+                    "Files"
                 ],
-                "summary": "Update pekerjaan",
+                "summary": "Upload sertifikat (PDF)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Pekerjaan ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "PDF file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "description": "Update Pekerjaan Request",
-                        "name": "pekerjaanRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdatePekerjaanRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID (untuk admin upload)",
+                        "name": "user_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1095,8 +1184,15 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1110,28 +1206,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/files/usage": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Hapus data pekerjaan (soft delete)",
+                "description": "Total byte dan jumlah file per kategori beserta kuota yang berlaku. User melihat miliknya sendiri, admin bisa memilih user lewat user_id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan_Alumni"
+                    "Files"
                 ],
-                "summary": "Soft delete pekerjaan",
+                "summary": "Pemakaian storage",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pekerjaan ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "User ID (khusus admin)",
+                        "name": "user_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1142,8 +1239,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1157,28 +1254,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/files/usage/top": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Restore data pekerjaan yang telah dihapus",
+                "description": "Laporan user dengan pemakaian storage terbesar, dihitung dari collection files (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan_Alumni"
+                    "Files"
                 ],
-                "summary": "Restore deleted pekerjaan",
+                "summary": "Pengguna storage terbesar",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Pekerjaan ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Jumlah user (default 10, maks 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1189,13 +1287,6 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1205,34 +1296,3048 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Alumni": {
-            "type": "object",
-            "properties": {
-                "alamat": {
-                    "type": "string"
-                },
-                "angkatan": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_deleted": {
-                    "type": "boolean"
-                },
-                "jurusan": {
-                    "type": "string"
-                },
-                "nama": {
+        },
+        "/files/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get detail file berdasarkan ID (admin semua file, user hanya miliknya)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Get file by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Hapus file dari database (admin semua file, user hanya miliknya). Isi file di storage hanya dihapus jika tidak ada file lain dengan hash SHA-256 yang sama. Referensi foto profil alumni, lampiran pekerjaan dan versi dokumen ikut dilepas. Penghapusan oleh admin dicatat di audit log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Delete file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Stream isi file dengan Content-Type dan nama asli. Mendukung Range (satu rentang), ETag / If-None-Match dan If-Range. Admin bisa mengunduh semua file, user hanya miliknya",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Download file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Tampilkan di browser (Content-Disposition inline)",
+                        "name": "inline",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ukuran thumbnail foto (small, medium, large)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rentang byte, mis. bytes=0-1023",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "416": {
+                        "description": "Requested Range Not Satisfiable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/link": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Membuat URL unduh bertanda tangan HMAC yang bisa dipakai tanpa Bearer token, mis. untuk \u003cimg\u003e di frontend atau link di laporan PDF. Link terikat ke file dan masa berlaku; dengan bind_user=true link juga terikat ke user pembuatnya dan hanya berlaku selama user tersebut masih ada dan masih boleh mengakses file",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Buat signed link file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Masa berlaku dalam detik (default 3600, maks 604800)",
+                        "name": "expires",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ikat link ke user yang membuatnya",
+                        "name": "bind_user",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/presign": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Membuat URL unduh langsung ke object storage yang berlaku sementara. Hanya untuk file di backend yang mendukung (s3); file lokal diunduh lewat /files/{id}/download",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Presigned URL file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Masa berlaku dalam detik (default 900, maks 604800)",
+                        "name": "expires",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/scan": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Memindai ulang isi file dengan scanner malware yang aktif (SCANNER_DRIVER), mis. untuk file yang masih pending karena scanner sempat tidak tersedia. File yang terinfeksi tetap dikarantina dan dicatat di audit log (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Pindai ulang file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengambil daftar alumni berdasarkan parameter pencarian, pengurutan, dan batas halaman dengan informasi meta",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mendapatkan daftar alumni dengan pencarian, sorting, dan pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom untuk sorting: nim, nama, jurusan, angkatan, tahun_lulus, email, created_at (default: nama)",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Urutan sorting: asc atau desc (default: asc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian di semua field",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan (tidak case-sensitive)",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response dengan data alumni dan meta informasi",
+                        "schema": {
+                            "$ref": "#/definitions/models.AlumniResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Menambahkan data alumni ke dalam database (hanya admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Menambah data alumni baru",
+                "parameters": [
+                    {
+                        "description": "Data Alumni Baru (NIM, Nama, Jurusan, Email, TahunLulus)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateAlumniRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response dengan data alumni baru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "request body tidak valid atau field kosong",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/all": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengambil seluruh data alumni dari database tanpa pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mendapatkan semua data alumni",
+                "responses": {
+                    "200": {
+                        "description": "success response dengan array data alumni",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/without-pekerjaan": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengambil daftar alumni yang belum memiliki pekerjaan dengan jumlah totalnya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mendapatkan alumni tanpa pekerjaan",
+                "responses": {
+                    "200": {
+                        "description": "success response dengan array data alumni tanpa pekerjaan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengambil data alumni tertentu berdasarkan ID. Dengan embed=file, metadata foto profil ikut disertakan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mendapatkan data alumni berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Isi file untuk menyertakan metadata foto profil",
+                        "name": "embed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response dengan data alumni",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "alumni tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengubah data alumni berdasarkan ID (hanya admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mengupdate data alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Alumni yang Diupdate (minimal NIM, Nama, Jurusan, Email, TahunLulus)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateAlumniRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response dengan data alumni yang diupdate",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "request body tidak valid atau field kosong",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "alumni tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengubah status alumni menjadi nonaktif tanpa menghapus data dari database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Menghapus alumni (soft delete)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response dengan message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "alumni tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengembalikan alumni yang sebelumnya dihapus (soft delete) menjadi aktif kembali",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Merestore alumni yang dihapus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response dengan message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/{id}/career": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengambil pekerjaan alumni secara kronologis beserta posisi saat ini, masa kerja, total pengalaman, jeda antar pekerjaan dan masa tunggu dari kelulusan ke pekerjaan pertama. Hanya untuk admin atau alumni yang bersangkutan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Timeline karir alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.KarirAlumni"
+                        }
+                    },
+                    "403": {
+                        "description": "bukan admin atau pemilik data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "alumni tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/alumni/{id}/foto": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Menghubungkan file foto yang sudah di-upload (/files/upload/foto) sebagai foto profil alumni. Admin untuk semua alumni, alumni hanya untuk dirinya sendiri dengan file miliknya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Mengatur foto profil alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID file foto",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetFotoAlumniRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response dengan data alumni dan foto",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "file_id kosong atau file bukan gambar",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "bukan data milik sendiri",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "alumni atau file tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "file masih dikarantina",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Menghapus hubungan foto profil dari data alumni. File fotonya tidak dihapus (gunakan DELETE /files/{id})",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Melepas foto profil alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response dengan message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "bukan data milik sendiri",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "alumni tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "error response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/audit-log": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengambil catatan aksi admin terbaru (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Daftar audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter aksi, mis. hapus_file",
+                        "name": "aksi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter entitas (nama collection), mis. files",
+                        "name": "entitas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter ID entitas",
+                        "name": "entitas_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data (default 50, maks 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/industri": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengambil turunan langsung dari kode induk; tanpa induk mengembalikan level kategori (A-U)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Industri"
+                ],
+                "summary": "Daftar taksonomi industri (KBLI)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kode induk (mis. J)",
+                        "name": "induk",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/industri/map": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mencocokkan bidang_industri teks bebas yang belum punya kode_industri dengan judul / kata kunci taksonomi. Default dry_run=true hanya menampilkan hasil (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Industri"
+                ],
+                "summary": "Petakan bidang_industri lama ke kode KBLI",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Hanya tampilkan hasil tanpa menyimpan (default true)",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/industri/search": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mencari kode KBLI berdasarkan awalan kode, judul atau kata kunci",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Industri"
+                ],
+                "summary": "Cari industri (autocomplete)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kata kunci atau awalan kode",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah hasil (default 10, maks 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/industri/{kode}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengambil satu kode KBLI beserta leluhur dan turunan langsungnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Industri"
+                ],
+                "summary": "Detail kode industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kode KBLI",
+                        "name": "kode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan-alumni": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get data pekerjaan dengan fitur pagination, search, dan sort",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Get pekerjaan with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort by field",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status: aktif, cuti, selesai",
+                        "name": "status_pekerjaan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Batas bawah gaji",
+                        "name": "gaji_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Batas atas gaji",
+                        "name": "gaji_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "bulanan",
+                        "description": "Periode gaji filter: bulanan atau tahunan",
+                        "name": "gaji_periode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "IDR",
+                        "description": "Mata uang gaji filter",
+                        "name": "gaji_mata_uang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PekerjaanResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Tambah data pekerjaan alumni baru (admin only). status_pekerjaan: aktif, cuti, selesai",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Create new pekerjaan",
+                "parameters": [
+                    {
+                        "description": "Create Pekerjaan Request",
+                        "name": "pekerjaanRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePekerjaanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan-alumni/alumni/{alumni_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get data pekerjaan berdasarkan alumni ID (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Get pekerjaan by alumni ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alumni ID",
+                        "name": "alumni_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan-alumni/trash": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get data pekerjaan yang telah dihapus (soft delete)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Get deleted pekerjaan (trash)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan-alumni/trash/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Hapus permanen data pekerjaan dari database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Hard delete pekerjaan permanently",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan-alumni/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get detail data pekerjaan berdasarkan ID. Dengan embed=file, metadata file lampiran ikut disertakan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Get pekerjaan by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Isi file untuk menyertakan metadata file lampiran",
+                        "name": "embed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update data pekerjaan (admin only). Tanggal kosong berarti tidak diubah; status mengikuti transisi aktif -\u003e cuti/selesai, cuti -\u003e aktif/selesai",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Update pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Pekerjaan Request",
+                        "name": "pekerjaanRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePekerjaanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Hapus data pekerjaan (soft delete)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Soft delete pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Restore data pekerjaan yang telah dihapus",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Restore deleted pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan-alumni/{id}/lampiran": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Menghubungkan file yang sudah di-upload (mis. surat keterangan kerja, sertifikat) ke data pekerjaan. Admin untuk semua pekerjaan, alumni hanya untuk pekerjaannya sendiri dengan file miliknya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Tambah lampiran pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "File dan kategori lampiran",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TambahLampiranRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pekerjaan-alumni/{id}/lampiran/{file_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Melepas file dari lampiran pekerjaan. File-nya tidak dihapus (gunakan DELETE /files/{id})",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan_Alumni"
+                ],
+                "summary": "Hapus lampiran pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "file_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pengajuan-pekerjaan": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Daftar pengajuan dari yang terlama (admin only). Default hanya yang pending",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pengajuan_Pekerjaan"
+                ],
+                "summary": "Antrian review pengajuan pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "pending",
+                        "description": "pending, disetujui, ditolak atau semua",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Alumni mengajukan pekerjaan baru, atau perubahan pekerjaan miliknya jika pekerjaan_id diisi. Pengajuan berstatus pending sampai direview admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pengajuan_Pekerjaan"
+                ],
+                "summary": "Ajukan pekerjaan (alumni)",
+                "parameters": [
+                    {
+                        "description": "Data pekerjaan yang diajukan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PengajuanPekerjaanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "masih ada pengajuan perubahan yang pending",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pengajuan-pekerjaan/saya": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Daftar pengajuan milik alumni yang login beserta status dan komentar admin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pengajuan_Pekerjaan"
+                ],
+                "summary": "Pengajuan pekerjaan saya",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter status: pending, disetujui, ditolak",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pengajuan-pekerjaan/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Admin dapat melihat semua pengajuan, alumni hanya miliknya sendiri",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pengajuan_Pekerjaan"
+                ],
+                "summary": "Detail pengajuan pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pengajuan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pengajuan-pekerjaan/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Data pengajuan divalidasi ulang lalu disimpan ke pekerjaan_alumni (baru) atau menimpa pekerjaan yang diubah (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pengajuan_Pekerjaan"
+                ],
+                "summary": "Setujui pengajuan pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pengajuan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Komentar admin (opsional)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewPengajuanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "pengajuan sudah direview atau pekerjaan sudah dihapus",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/pengajuan-pekerjaan/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Menolak pengajuan dengan komentar wajib yang akan dilihat alumni (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pengajuan_Pekerjaan"
+                ],
+                "summary": "Tolak pengajuan pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pengajuan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan penolakan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewPengajuanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "pengajuan sudah direview",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/perusahaan": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengambil master data perusahaan, dapat dicari berdasarkan nama atau alias",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perusahaan"
+                ],
+                "summary": "Daftar perusahaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kata kunci nama / alias",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Menambah master data perusahaan (admin only). Nama dan alias harus unik setelah dinormalisasi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perusahaan"
+                ],
+                "summary": "Tambah perusahaan",
+                "parameters": [
+                    {
+                        "description": "Data perusahaan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PerusahaanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/perusahaan/match": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Menghubungkan pekerjaan yang belum punya perusahaan_id berdasarkan nama / alias yang dinormalisasi. Nama yang tidak cocok dikembalikan beserta saran perusahaan yang mirip (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perusahaan"
+                ],
+                "summary": "Cocokkan pekerjaan dengan master perusahaan",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Hanya laporan, tidak mengubah data",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Buat perusahaan baru untuk nama yang tidak cocok dan tidak punya saran",
+                        "name": "create_missing",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/perusahaan/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengambil data perusahaan beserta semua alumni yang pernah / sedang bekerja di sana",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perusahaan"
+                ],
+                "summary": "Detail perusahaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Perusahaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mengubah nama kanonik, alias, industri dan lokasi perusahaan (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perusahaan"
+                ],
+                "summary": "Update perusahaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Perusahaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data perusahaan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PerusahaanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/perusahaan/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Menggabungkan perusahaan source_ids ke perusahaan tujuan: nama dan alias menjadi alias, pekerjaan dipindahkan, lalu perusahaan sumber dihapus (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perusahaan"
+                ],
+                "summary": "Gabungkan perusahaan duplikat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Perusahaan tujuan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID perusahaan yang digabungkan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergePerusahaanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/statistik/gaji": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Jumlah pekerjaan per rentang gaji bulanan (IDR, titik tengah gaji_min/gaji_max, gaji tahunan dibagi 12) (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Statistik rentang gaji",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian (alumni dan pekerjaan)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status: aktif, cuti, selesai",
+                        "name": "status_pekerjaan",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/statistik/industri": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Jumlah pekerjaan dan alumni per kode KBLI (kategori / golongan_pokok) atau per teks bidang_industri (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Statistik distribusi bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "kategori",
+                        "description": "kategori, golongan_pokok atau bidang_industri (teks bebas)",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian (alumni dan pekerjaan)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status: aktif, cuti, selesai",
+                        "name": "status_pekerjaan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Batas bawah gaji",
+                        "name": "gaji_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Batas atas gaji",
+                        "name": "gaji_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "bulanan",
+                        "description": "Periode gaji filter",
+                        "name": "gaji_periode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/statistik/keterserapan": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Persentase alumni yang pernah / sedang bekerja (status aktif atau cuti), dapat dikelompokkan per jurusan, angkatan atau tahun_lulus (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Statistik keterserapan kerja alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kelompok: jurusan, angkatan, tahun_lulus (kosong = total)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian alumni",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/statistik/lokasi": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Jumlah pekerjaan dan alumni per lokasi_kerja (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Statistik distribusi lokasi kerja",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian (alumni dan pekerjaan)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status: aktif, cuti, selesai",
+                        "name": "status_pekerjaan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Batas bawah gaji",
+                        "name": "gaji_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Batas atas gaji",
+                        "name": "gaji_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "bulanan",
+                        "description": "Periode gaji filter",
+                        "name": "gaji_periode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/unair/statistik/masa-tunggu": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Rata-rata, minimum, maksimum masa tunggu (bulan) dari kelulusan ke pekerjaan pertama serta jumlah alumni dengan masa tunggu \u003c= 6 bulan (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistik"
+                ],
+                "summary": "Statistik masa tunggu kerja pertama",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kelompok: jurusan, angkatan, tahun_lulus (kosong = total)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian alumni",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Alumni": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "foto": {
+                    "description": "diisi jika diminta dengan ?embed=file",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FileResponse"
+                        }
+                    ]
+                },
+                "foto_file_id": {
+                    "description": "foto profil di collection files",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
                     "type": "string"
                 },
                 "nim": {
@@ -1278,46 +4383,254 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
-                "jurusan": {
-                    "type": "string"
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "type": "string"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "string ID dari frontend",
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateDokumenRequest": {
+            "type": "object",
+            "properties": {
+                "jenis": {
+                    "description": "foto_profil, ijazah, transkrip atau sertifikat",
+                    "type": "string"
+                },
+                "nama": {
+                    "description": "opsional",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "wajib untuk admin, diabaikan untuk user lain",
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreatePekerjaanRequest": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "description": "string dulu, nanti dikonversi ke ObjectID",
+                    "type": "string"
+                },
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "deskripsi_pekerjaan": {
+                    "type": "string"
+                },
+                "gaji_mata_uang": {
+                    "description": "kode ISO 4217, default IDR",
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
+                    "description": "bulanan atau tahunan",
+                    "type": "string"
+                },
+                "gaji_range": {
+                    "description": "opsional, teks bebas (mis. \"5-7 juta\") jika gaji_min/gaji_max kosong",
+                    "type": "string"
+                },
+                "kode_industri": {
+                    "description": "kode KBLI, bidang_industri diisi dari judulnya",
+                    "type": "string"
+                },
+                "lokasi_kerja": {
+                    "type": "string"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "perusahaan_id": {
+                    "description": "opsional, jika kosong dicocokkan dari nama_perusahaan",
+                    "type": "string"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "status_pekerjaan": {
+                    "type": "string"
+                },
+                "tanggal_mulai_kerja": {
+                    "description": "YYYY-MM-DD, RFC3339, DD-MM-YYYY atau DD/MM/YYYY",
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Durasi": {
+            "type": "object",
+            "properties": {
+                "bulan": {
+                    "type": "integer"
+                },
+                "hari": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FileResponse": {
+            "type": "object",
+            "properties": {
+                "download_url": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kategori": {
+                    "type": "string"
+                },
+                "original_name": {
+                    "type": "string"
+                },
+                "scan_status": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "thumbnails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FileThumbnailResponse"
+                    }
+                },
+                "uploaded_at": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FileThumbnailResponse": {
+            "type": "object",
+            "properties": {
+                "download_url": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.JedaKarir": {
+            "type": "object",
+            "properties": {
+                "dari": {
+                    "type": "string"
+                },
+                "durasi": {
+                    "$ref": "#/definitions/models.Durasi"
+                },
+                "sampai": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.KarirAlumni": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "bekerja_sebelum_lulus": {
+                    "type": "boolean"
+                },
+                "jeda": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JedaKarir"
+                    }
+                },
+                "masa_tunggu_pekerjaan_pertama": {
+                    "description": "Waktu dari tanggal lulus (perkiraan, lihat utils.GraduationDate) ke pekerjaan pertama",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Durasi"
+                        }
+                    ]
                 },
                 "nama": {
                     "type": "string"
                 },
-                "nim": {
-                    "type": "string"
+                "pekerjaan": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.KarirPekerjaan"
+                    }
                 },
-                "no_telepon": {
-                    "type": "string"
+                "posisi_saat_ini": {
+                    "$ref": "#/definitions/models.KarirPekerjaan"
                 },
                 "tahun_lulus": {
                     "type": "integer"
                 },
-                "user_id": {
-                    "description": "string ID dari frontend",
-                    "type": "string"
+                "total_pengalaman": {
+                    "$ref": "#/definitions/models.Durasi"
                 }
             }
         },
-        "models.CreatePekerjaanRequest": {
+        "models.KarirPekerjaan": {
             "type": "object",
             "properties": {
-                "alumni_id": {
-                    "description": "string dulu, nanti dikonversi ke ObjectID",
-                    "type": "string"
-                },
                 "bidang_industri": {
                     "type": "string"
                 },
-                "deskripsi_pekerjaan": {
-                    "type": "string"
-                },
-                "gaji_range": {
+                "id": {
                     "type": "string"
                 },
                 "lokasi_kerja": {
                     "type": "string"
                 },
+                "masa_kerja": {
+                    "$ref": "#/definitions/models.Durasi"
+                },
                 "nama_perusahaan": {
                     "type": "string"
                 },
@@ -1328,7 +4641,6 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "tanggal_mulai_kerja": {
-                    "description": "format ISO date",
                     "type": "string"
                 },
                 "tanggal_selesai_kerja": {
@@ -1336,6 +4648,31 @@ const docTemplate = `{
                 }
             }
         },
+        "models.LampiranPekerjaan": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "file": {
+                    "description": "diisi jika diminta dengan ?embed=file",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FileResponse"
+                        }
+                    ]
+                },
+                "file_id": {
+                    "type": "string"
+                },
+                "kategori": {
+                    "type": "string"
+                },
+                "keterangan": {
+                    "type": "string"
+                }
+            }
+        },
         "models.LoginRequest": {
             "type": "object",
             "properties": {
@@ -1347,6 +4684,17 @@ const docTemplate = `{
                 }
             }
         },
+        "models.MergePerusahaanRequest": {
+            "type": "object",
+            "properties": {
+                "source_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "models.MetaInfo": {
             "type": "object",
             "properties": {
@@ -1373,6 +4721,55 @@ const docTemplate = `{
                 }
             }
         },
+        "models.MissingFile": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "file_id": {
+                    "type": "string"
+                },
+                "fixed": {
+                    "type": "boolean"
+                },
+                "original_name": {
+                    "type": "string"
+                },
+                "storage_key": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrphanObject": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fixed": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "mod_time": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.Pekerjaan": {
             "type": "object",
             "properties": {
@@ -1388,18 +4785,46 @@ const docTemplate = `{
                 "deskripsi_pekerjaan": {
                     "type": "string"
                 },
+                "gaji_mata_uang": {
+                    "description": "kode ISO 4217, default IDR",
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
+                    "description": "bulanan atau tahunan",
+                    "type": "string"
+                },
                 "gaji_range": {
+                    "description": "teks gaji lama yang gagal di-parse migrasi, dihapus saat pekerjaan di-update",
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "kode_industri": {
+                    "description": "kode KBLI",
+                    "type": "string"
+                },
+                "lampiran": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LampiranPekerjaan"
+                    }
+                },
                 "lokasi_kerja": {
                     "type": "string"
                 },
                 "nama_perusahaan": {
                     "type": "string"
                 },
+                "perusahaan_id": {
+                    "type": "string"
+                },
                 "posisi_jabatan": {
                     "type": "string"
                 },
@@ -1431,6 +4856,199 @@ const docTemplate = `{
                 }
             }
         },
+        "models.PengajuanPekerjaanRequest": {
+            "type": "object",
+            "properties": {
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "deskripsi_pekerjaan": {
+                    "type": "string"
+                },
+                "gaji_mata_uang": {
+                    "description": "kode ISO 4217, default IDR",
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
+                    "description": "bulanan atau tahunan",
+                    "type": "string"
+                },
+                "gaji_range": {
+                    "description": "opsional, teks bebas (mis. \"5-7 juta\") jika gaji_min/gaji_max kosong",
+                    "type": "string"
+                },
+                "kode_industri": {
+                    "description": "kode KBLI, bidang_industri diisi dari judulnya",
+                    "type": "string"
+                },
+                "lokasi_kerja": {
+                    "type": "string"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "pekerjaan_id": {
+                    "type": "string"
+                },
+                "perusahaan_id": {
+                    "description": "opsional, jika kosong dicocokkan dari nama_perusahaan",
+                    "type": "string"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "status_pekerjaan": {
+                    "type": "string"
+                },
+                "tanggal_mulai_kerja": {
+                    "description": "YYYY-MM-DD, RFC3339, DD-MM-YYYY atau DD/MM/YYYY",
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PerusahaanRequest": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "lokasi": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReconcileResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "checked_files": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "min_age_minutes": {
+                    "type": "integer"
+                },
+                "missing_files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MissingFile"
+                    }
+                },
+                "orphan_objects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrphanObject"
+                    }
+                },
+                "restored_files": {
+                    "description": "file bertanda missing_at yang isinya sudah ada lagi",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scanned_objects": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReviewPengajuanRequest": {
+            "type": "object",
+            "properties": {
+                "komentar": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RollbackDokumenRequest": {
+            "type": "object",
+            "properties": {
+                "versi": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SetFotoAlumniRequest": {
+            "type": "object",
+            "properties": {
+                "file_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SetStorageQuotaRequest": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string"
+                },
+                "max_bytes": {
+                    "description": "0 = tanpa batas",
+                    "type": "integer"
+                },
+                "max_files": {
+                    "description": "0 = tanpa batas",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TambahLampiranRequest": {
+            "type": "object",
+            "properties": {
+                "file_id": {
+                    "type": "string"
+                },
+                "kategori": {
+                    "description": "surat_keterangan_kerja, kontrak_kerja, sertifikat atau lainnya",
+                    "type": "string"
+                },
+                "keterangan": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TambahVersiDokumenRequest": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string"
+                },
+                "file_id": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UpdateAlumniRequest": {
             "type": "object",
             "properties": {
@@ -1472,7 +5090,26 @@ const docTemplate = `{
                 "deskripsi_pekerjaan": {
                     "type": "string"
                 },
+                "gaji_mata_uang": {
+                    "description": "kode ISO 4217, default IDR",
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
+                    "description": "bulanan atau tahunan",
+                    "type": "string"
+                },
                 "gaji_range": {
+                    "description": "opsional, teks bebas (mis. \"5-7 juta\") jika gaji_min/gaji_max kosong",
+                    "type": "string"
+                },
+                "kode_industri": {
+                    "description": "kode KBLI, bidang_industri diisi dari judulnya",
                     "type": "string"
                 },
                 "lokasi_kerja": {
@@ -1481,6 +5118,10 @@ const docTemplate = `{
                 "nama_perusahaan": {
                     "type": "string"
                 },
+                "perusahaan_id": {
+                    "description": "opsional, jika kosong dicocokkan dari nama_perusahaan",
+                    "type": "string"
+                },
                 "posisi_jabatan": {
                     "type": "string"
                 },
@@ -1488,6 +5129,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "tanggal_mulai_kerja": {
+                    "description": "YYYY-MM-DD, RFC3339, DD-MM-YYYY atau DD/MM/YYYY",
                     "type": "string"
                 },
                 "tanggal_selesai_kerja": {
@@ -1,11 +1,18 @@
 package main
 
 import (
-	"log"
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"crud-app/config"
 	"crud-app/database"
 	"crud-app/route"
+	"crud-app/scanner"
+	"crud-app/storage"
+	"crud-app/tracing"
 
 	_ "crud-app/docs"
 
@@ -27,11 +34,27 @@ func main() {
 	config.LoadEnv()
 	config.InitLogger()
 
+	shutdownTracing, err := tracing.InitFromEnv(context.Background())
+	if err != nil {
+		config.Fatal("Gagal inisialisasi tracing", "err", err)
+	}
+
 	db := database.ConnectMongo()
+	database.RunMigrations(db)
+
+	store, err := storage.NewFromEnv()
+	if err != nil {
+		config.Fatal("Gagal inisialisasi storage", "err", err)
+	}
+
+	scan, err := scanner.NewFromEnv()
+	if err != nil {
+		config.Fatal("Gagal inisialisasi scanner malware", "err", err)
+	}
 
 	app := config.NewApp()
 
-	route.SetupRoutes(app, db)
+	route.SetupRoutes(app, db, store, scan, config.LoadTimeouts())
 
 	// Setup Swagger documentation
 	app.Get("/swagger/*", fiberSwagger.WrapHandler)
@@ -41,9 +64,26 @@ func main() {
 		return c.Redirect("/swagger/index.html", 301)
 	})
 
+	// Hentikan server dengan rapi saat SIGINT/SIGTERM agar span yang tersisa sempat dikirim
+	go func() {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		<-ctx.Done()
+		if err := app.Shutdown(); err != nil {
+			config.Logger.Error("Gagal menghentikan server", "err", err)
+		}
+	}()
+
 	// Jalankan server
 	port := config.GetEnv("APP_PORT", "3000")
-	config.Logger.Println("Server running at http://localhost:" + port)
-	config.Logger.Println("Swagger UI available at http://localhost:" + port + "/swagger/index.html")
-	log.Fatal(app.Listen(":" + port))
+	config.Logger.Info("Server running", "url", "http://localhost:"+port, "swagger", "http://localhost:"+port+"/swagger/index.html")
+	if err := app.Listen(":" + port); err != nil {
+		config.Fatal("Server berhenti", "err", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		config.Logger.Error("Gagal mengirim sisa span", "err", err)
+	}
 }
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Label route untuk request yang tidak cocok dengan route mana pun (404), agar path
+// acak tidak menambah jumlah seri metric
+const unmatchedRoute = "unmatched"
+
+// Middleware mencatat durasi setiap request HTTP per method, template route
+// (mis. /unair/alumni/:id) dan status. Template route dan status final diisi
+// middleware.HandlerResult yang dipasang setelahnya.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		httpRequestsInFlight.Inc()
+		defer httpRequestsInFlight.Dec()
+
+		err := c.Next()
+
+		route, ok := c.Locals("route").(string)
+		if !ok {
+			route = unmatchedRoute
+		}
+		httpRequestDuration.
+			WithLabelValues(c.Method(), route, strconv.Itoa(c.Response().StatusCode())).
+			Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// Handler menyajikan metric dalam format Prometheus. Jika token diisi (METRICS_TOKEN),
+// request harus membawa header Authorization: Bearer <token>.
+func Handler(token string) fiber.Handler {
+	promHandler := adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+
+	return func(c *fiber.Ctx) error {
+		if token != "" {
+			expected := "Bearer " + token
+			if subtle.ConstantTimeCompare([]byte(c.Get(fiber.HeaderAuthorization)), []byte(expected)) != 1 {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "Token metrics tidak valid",
+				})
+			}
+		}
+		return promHandler(c)
+	}
+}
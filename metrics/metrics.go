@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "crud_app"
+
+// Registry berisi semua metric aplikasi beserta metric runtime Go dan proses
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Durasi request HTTP per method, template route dan status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Jumlah request HTTP yang sedang diproses.",
+	})
+
+	mongoOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongodb_operation_duration_seconds",
+		Help:      "Durasi command MongoDB per collection dan command.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"collection", "command"})
+
+	mongoOperationErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mongodb_operation_errors_total",
+		Help:      "Jumlah command MongoDB yang gagal per collection dan command.",
+	}, []string{"collection", "command"})
+
+	logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_logins_total",
+		Help:      "Jumlah percobaan login per hasil (success, failure).",
+	}, []string{"result"})
+
+	uploads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "file_uploads_total",
+		Help:      "Jumlah file yang berhasil diupload per kategori.",
+	}, []string{"kategori"})
+
+	uploadBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "file_upload_bytes_total",
+		Help:      "Total byte file yang berhasil diupload per kategori.",
+	}, []string{"kategori"})
+
+	trashMoves = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "trash_moves_total",
+		Help:      "Jumlah data yang dipindahkan ke trash (soft delete) per entitas.",
+	}, []string{"entitas"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestDuration,
+		httpRequestsInFlight,
+		mongoOperationDuration,
+		mongoOperationErrors,
+		logins,
+		uploads,
+		uploadBytes,
+		trashMoves,
+	)
+}
+
+// Hasil login untuk LoginAttempt
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+)
+
+// LoginAttempt mencatat satu percobaan login
+func LoginAttempt(result string) {
+	logins.WithLabelValues(result).Inc()
+}
+
+// FileUploaded mencatat satu upload file yang berhasil disimpan
+func FileUploaded(kategori string, size int64) {
+	uploads.WithLabelValues(kategori).Inc()
+	uploadBytes.WithLabelValues(kategori).Add(float64(size))
+}
+
+// MovedToTrash mencatat data yang dipindahkan ke trash (soft delete)
+func MovedToTrash(entitas string) {
+	trashMoves.WithLabelValues(entitas).Inc()
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"crud-app/utils"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+type mongoCommand struct {
+	collection string
+	started    time.Time
+}
+
+// NewMongoMonitor membuat CommandMonitor yang mencatat jumlah, durasi dan error setiap
+// command MongoDB per collection. Dipasang lewat options.Client().SetMonitor.
+func NewMongoMonitor() *event.CommandMonitor {
+	var inFlight sync.Map // request ID command -> mongoCommand
+
+	finish := func(requestID int64, command string, failed bool) {
+		value, ok := inFlight.LoadAndDelete(requestID)
+		if !ok {
+			return
+		}
+		cmd := value.(mongoCommand)
+		mongoOperationDuration.WithLabelValues(cmd.collection, command).Observe(time.Since(cmd.started).Seconds())
+		if failed {
+			mongoOperationErrors.WithLabelValues(cmd.collection, command).Inc()
+		}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+			if utils.IsInternalMongoCommand(evt.CommandName) {
+				return
+			}
+			inFlight.Store(evt.RequestID, mongoCommand{
+				collection: utils.MongoCommandCollection(evt.CommandName, evt.Command),
+				started:    time.Now(),
+			})
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.RequestID, evt.CommandName, false)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.RequestID, evt.CommandName, true)
+		},
+	}
+}
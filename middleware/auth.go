@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"crud-app/config"
 	"crud-app/utils"
+	"log/slog"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -41,6 +43,12 @@ func AuthRequired() fiber.Handler {
 			c.Locals("alumni_id", claims.AlumniID)
 		}
 
+		// user_id dan role ikut tercatat di setiap log request ini
+		c.SetUserContext(config.WithLogAttrs(c.UserContext(),
+			slog.String("user_id", claims.UserID.Hex()),
+			slog.String("role", claims.Role),
+		))
+
 		return c.Next()
 	}
 }
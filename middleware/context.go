@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestContext memasang context request (c.UserContext) yang dibatalkan saat handler
+// selesai, saat batas waktu request habis, atau saat server shutdown. fasthttp tidak
+// memberi tahu jika client memutus koneksi di tengah handler, sehingga batas waktu inilah
+// yang menghentikan query milik request yang sudah ditinggalkan.
+func RequestContext(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.Context(), timeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
@@ -1,13 +1,77 @@
 package middleware
 
 import (
-    "fmt"
-    "github.com/gofiber/fiber/v2"
+	"log/slog"
+	"strings"
+	"time"
+
+	"crud-app/config"
+	"crud-app/utils"
+
+	"github.com/gofiber/fiber/v2"
 )
 
-func LoggerMiddleware(c *fiber.Ctx) error {
-    fmt.Printf("Request: %s %s\n", c.Method(), c.OriginalURL())
-    err := c.Next()
-    fmt.Printf("Response: %d\n", c.Response().StatusCode())
-    return err
-}
\ No newline at end of file
+// RequestID memakai header X-Request-ID dari client jika valid, jika tidak membuat ID baru.
+// ID dikirim balik di header response dan disimpan di context request, sehingga ikut
+// tercatat di setiap log dan comment query Mongo milik request ini.
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(fiber.HeaderXRequestID)
+		if !utils.ValidRequestID(id) {
+			id = utils.NewRequestID()
+		}
+
+		c.Set(fiber.HeaderXRequestID, id)
+		c.Locals("request_id", id)
+
+		ctx := utils.WithRequestID(c.UserContext(), id)
+		c.SetUserContext(config.WithLogAttrs(ctx, slog.String("request_id", id)))
+		return c.Next()
+	}
+}
+
+// AccessLog mencatat satu log per request: method, path, query (nilai data pribadi
+// disamarkan), status dan latency, beserta request_id, user_id dan role dari context.
+// Status sudah final karena error handler diproses HandlerResult.
+func AccessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		config.Logger.LogAttrs(c.UserContext(), level, "request",
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.String("query", redactedQuery(c)),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		)
+		return err
+	}
+}
+
+// redactedQuery menyusun ulang query string dengan nilai parameter sensitif disamarkan
+func redactedQuery(c *fiber.Ctx) string {
+	var b strings.Builder
+	c.Request().URI().QueryArgs().VisitAll(func(key, value []byte) {
+		if b.Len() > 0 {
+			b.WriteByte('&')
+		}
+		b.Write(key)
+		b.WriteByte('=')
+		if config.RedactedLogKey(string(key)) {
+			b.WriteString("[REDACTED]")
+		} else {
+			b.Write(value)
+		}
+	})
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// HandlerResult dipasang sebagai middleware global terakhir dan mencatat hasil handler
+// untuk middleware di luarnya (tracing, access log, metrics):
+//   - error dari handler diproses ErrorHandler sekali di sini, sehingga status response
+//     sudah final saat middleware luar membacanya; error aslinya disimpan di c.Locals("error")
+//   - template route yang cocok (mis. /unair/alumni/:id) disimpan di c.Locals("route").
+//     Jika setelah c.Next() route-nya masih route middleware ini, tidak ada route yang cocok
+//     dan c.Locals("route") tidak diisi.
+func HandlerResult() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		self := c.Route()
+
+		if err := c.Next(); err != nil {
+			c.Locals("error", err)
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		if r := c.Route(); r != self {
+			c.Locals("route", r.Path)
+		}
+		return nil
+	}
+}
@@ -2,14 +2,32 @@ package route
 
 import (
 	"crud-app/app/repository"
+	"crud-app/config"
 	"crud-app/app/service"
+	"crud-app/metrics"
 	"crud-app/middleware"
+	"crud-app/scanner"
+	"crud-app/storage"
+	"crud-app/tracing"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func SetupRoutes(app *fiber.App, db *mongo.Database) {
+func SetupRoutes(app *fiber.App, db *mongo.Database, store *storage.Manager, scan scanner.Scanner, timeouts config.Timeouts) {
+	// Context request untuk semua handler, dibatalkan saat request selesai atau melewati batas waktu
+	app.Use(middleware.RequestContext(timeouts.Request))
+	// Request ID, span trace dan access log (JSON) untuk semua request
+	app.Use(middleware.RequestID())
+	app.Use(tracing.Middleware())
+	app.Use(middleware.AccessLog())
+	app.Use(metrics.Middleware())
+	// Error handler dan template route, harus menjadi middleware global terakhir
+	app.Use(middleware.HandlerResult())
+
+	// Metric Prometheus, dilindungi token jika METRICS_TOKEN diisi
+	app.Get("/metrics", metrics.Handler(config.GetEnv("METRICS_TOKEN", "")))
+
 	// -------------------------
 	// Base groups
 	// -------------------------
@@ -20,7 +38,7 @@ func SetupRoutes(app *fiber.App, db *mongo.Database) {
 	// AUTH ROUTES
 	// =========================
 	authRepo := repository.NewAuthRepository(db)
-	authService := service.NewAuthService(authRepo)
+	authService := service.NewAuthService(authRepo, timeouts)
 
 	api.Post("/login", authService.Login)
 
@@ -32,23 +50,30 @@ func SetupRoutes(app *fiber.App, db *mongo.Database) {
 	// ALUMNI ROUTES
 	// =========================
 	alumniRepo := repository.NewAlumniRepository(db)
-	alumniService := service.NewAlumniService(alumniRepo)
+	fileRepo := repository.NewFileRepository(db)
+	alumniService := service.NewAlumniService(alumniRepo, fileRepo, timeouts)
+	pekerjaanRepo := repository.NewPekerjaanRepository(db)
+	karirService := service.NewKarirService(alumniRepo, pekerjaanRepo, timeouts)
 
 	alumni := unair.Group("/alumni")
 	alumni.Get("/", alumniService.GetAlumniService)
 	alumni.Get("/without-pekerjaan", middleware.AuthRequired(), alumniService.GetWithoutPekerjaan)
 	alumni.Get("/:id", middleware.AuthRequired(), alumniService.GetByID)
+	alumni.Get("/:id/career", middleware.AuthRequired(), karirService.GetCareer)
 
 	alumni.Post("/", middleware.AuthRequired(), middleware.AdminOnly(), alumniService.Create)
 	alumni.Put("/:id", middleware.AuthRequired(), middleware.AdminOnly(), alumniService.Update)
 	alumni.Delete("/:id", middleware.AuthRequired(), alumniService.SoftDelete)
 	alumni.Patch("/:id", middleware.AuthRequired(), alumniService.Restore)
+	alumni.Put("/:id/foto", middleware.AuthRequired(), alumniService.SetFoto)
+	alumni.Delete("/:id/foto", middleware.AuthRequired(), alumniService.DeleteFoto)
 
 	// =========================
 	// PEKERJAAN ALUMNI ROUTES
 	// =========================
-	pekerjaanRepo := repository.NewPekerjaanRepository(db)
-	pekerjaanService := service.NewPekerjaanService(pekerjaanRepo)
+	perusahaanRepo := repository.NewPerusahaanRepository(db)
+	industriRepo := repository.NewIndustriRepository(db)
+	pekerjaanService := service.NewPekerjaanService(pekerjaanRepo, perusahaanRepo, industriRepo, fileRepo, timeouts)
 
 	pekerjaan := unair.Group("/pekerjaan-alumni")
 	pekerjaan.Get("/", pekerjaanService.GetPekerjaanService)
@@ -60,23 +85,122 @@ func SetupRoutes(app *fiber.App, db *mongo.Database) {
 	pekerjaan.Put("/:id", middleware.AuthRequired(), middleware.AdminOnly(), pekerjaanService.Update)
 	pekerjaan.Delete("/:id", middleware.AuthRequired(), pekerjaanService.SoftDelete)
 	pekerjaan.Patch("/:id", middleware.AuthRequired(), pekerjaanService.Restore)
+	pekerjaan.Post("/:id/lampiran", middleware.AuthRequired(), pekerjaanService.AddLampiran)
+	pekerjaan.Delete("/:id/lampiran/:file_id", middleware.AuthRequired(), pekerjaanService.RemoveLampiran)
 
 	// Opsional tambahan untuk restore dan hard delete
 	pekerjaan.Put("/restore/:id", middleware.AuthRequired(), pekerjaanService.Restore)
 	pekerjaan.Delete("/trash/delete/:id", middleware.AuthRequired(), pekerjaanService.Delete)
 
+	// =========================
+	// PENGAJUAN PEKERJAAN ROUTES
+	// =========================
+	pengajuanRepo := repository.NewPengajuanPekerjaanRepository(db)
+	pengajuanService := service.NewPengajuanPekerjaanService(pengajuanRepo, pekerjaanRepo, pekerjaanService, timeouts)
+
+	pengajuan := unair.Group("/pengajuan-pekerjaan", middleware.AuthRequired())
+	pengajuan.Post("/", pengajuanService.Submit)
+	pengajuan.Get("/saya", pengajuanService.GetMine)
+	pengajuan.Get("/", middleware.AdminOnly(), pengajuanService.GetAll)
+	pengajuan.Get("/:id", pengajuanService.GetByID)
+	pengajuan.Post("/:id/approve", middleware.AdminOnly(), pengajuanService.Approve)
+	pengajuan.Post("/:id/reject", middleware.AdminOnly(), pengajuanService.Reject)
+
+	// =========================
+	// PERUSAHAAN ROUTES
+	// =========================
+	perusahaanService := service.NewPerusahaanService(perusahaanRepo, timeouts)
+
+	perusahaan := unair.Group("/perusahaan", middleware.AuthRequired())
+	perusahaan.Get("/", perusahaanService.GetAll)
+	perusahaan.Get("/:id", perusahaanService.GetByID)
+	perusahaan.Post("/", middleware.AdminOnly(), perusahaanService.Create)
+	perusahaan.Post("/match", middleware.AdminOnly(), perusahaanService.Match)
+	perusahaan.Put("/:id", middleware.AdminOnly(), perusahaanService.Update)
+	perusahaan.Post("/:id/merge", middleware.AdminOnly(), perusahaanService.Merge)
+
+	// =========================
+	// INDUSTRI (KBLI) ROUTES
+	// =========================
+	industriService := service.NewIndustriService(industriRepo, timeouts)
+
+	industri := unair.Group("/industri", middleware.AuthRequired())
+	industri.Get("/", industriService.GetAll)
+	industri.Get("/search", industriService.Search)
+	industri.Post("/map", middleware.AdminOnly(), industriService.Map)
+	industri.Get("/:kode", industriService.GetByKode)
+
+	// =========================
+	// STATISTIK ROUTES
+	// =========================
+	statistikRepo := repository.NewStatistikRepository(db)
+	statistikService := service.NewStatistikService(statistikRepo, timeouts)
+
+	statistik := unair.Group("/statistik", middleware.AuthRequired(), middleware.AdminOnly())
+	statistik.Get("/keterserapan", statistikService.GetKeterserapan)
+	statistik.Get("/masa-tunggu", statistikService.GetMasaTunggu)
+	statistik.Get("/industri", statistikService.GetDistribusiIndustri)
+	statistik.Get("/lokasi", statistikService.GetDistribusiLokasi)
+	statistik.Get("/gaji", statistikService.GetDistribusiGaji)
+
+	// =========================
+	// AUDIT LOG ROUTES
+	// =========================
+	auditRepo := repository.NewAuditRepository(db)
+	auditService := service.NewAuditService(auditRepo, timeouts)
+
+	unair.Get("/audit-log", middleware.AuthRequired(), middleware.AdminOnly(), auditService.GetAll)
+
 	// =========================
 	// UPLOAD FILES ROUTES
 	// =========================
 	files := app.Group("/files")
-	
-	fileRepo := repository.NewFileRepository(db)
-	uploadPath := "./uploads"
-	fileService := service.NewFileService(fileRepo, uploadPath)
+
+	tusRepo := repository.NewTusUploadRepository(db)
+	quotaRepo := repository.NewStorageQuotaRepository(db)
+	fileBlobRepo := repository.NewFileBlobRepository(db)
+	dokumenRepo := repository.NewDokumenRepository(db)
+	fileService := service.NewFileService(service.FileServiceDeps{
+		Files:      fileRepo,
+		Blobs:      fileBlobRepo,
+		Dokumen:    dokumenRepo,
+		Audit:      auditRepo,
+		Alumni:     alumniRepo,
+		Pekerjaan:  pekerjaanRepo,
+		TusUploads: tusRepo,
+		Auth:       authRepo,
+		Quotas:     quotaRepo,
+		Storage:    store,
+		Scanner:    scan,
+	}, config.LoadUploadConfig(), timeouts)
 
 	files.Post("/upload/foto", middleware.AuthRequired(), fileService.UploadFoto)
 	files.Post("/upload/sertifikat", middleware.AuthRequired(), fileService.UploadSertifikat)
 	files.Get("/", middleware.AuthRequired(), fileService.GetAllFiles)
+	files.Get("/usage", middleware.AuthRequired(), fileService.GetUsage)
+	files.Get("/usage/top", middleware.AuthRequired(), middleware.AdminOnly(), fileService.GetTopUsage)
+	files.Put("/quota/:user_id", middleware.AuthRequired(), middleware.AdminOnly(), fileService.SetQuota)
+	files.Delete("/quota/:user_id", middleware.AuthRequired(), middleware.AdminOnly(), fileService.DeleteQuota)
+	files.Get("/export", middleware.AuthRequired(), fileService.ExportFiles)
+	files.Post("/dokumen", middleware.AuthRequired(), fileService.CreateDokumen)
+	files.Get("/dokumen", middleware.AuthRequired(), fileService.ListDokumen)
+	files.Get("/dokumen/:id", middleware.AuthRequired(), fileService.GetDokumen)
+	files.Get("/dokumen/:id/versions", middleware.AuthRequired(), fileService.ListDokumenVersions)
+	files.Post("/dokumen/:id/versions", middleware.AuthRequired(), fileService.AddDokumenVersion)
+	files.Post("/dokumen/:id/rollback", middleware.AuthRequired(), fileService.RollbackDokumen)
+	files.Post("/reconcile", middleware.AuthRequired(), middleware.AdminOnly(), fileService.ReconcileFiles)
+	files.Get("/signed/:id", fileService.DownloadSignedFile)
 	files.Get("/:id", middleware.AuthRequired(), fileService.GetFileByID)
+	files.Get("/:id/download", middleware.AuthRequired(), fileService.DownloadFile)
+	files.Get("/:id/presign", middleware.AuthRequired(), fileService.PresignFile)
+	files.Get("/:id/link", middleware.AuthRequired(), fileService.CreateFileLink)
 	files.Delete("/:id", middleware.AuthRequired(), fileService.DeleteFile)
+	files.Post("/:id/scan", middleware.AuthRequired(), middleware.AdminOnly(), fileService.ScanFile)
+
+	// Upload resumable (tus 1.0.0)
+	files.Options("/tus", fileService.TusOptions)
+	files.Post("/tus", middleware.AuthRequired(), fileService.TusCreate)
+	files.Head("/tus/:id", middleware.AuthRequired(), fileService.TusHead)
+	files.Patch("/tus/:id", middleware.AuthRequired(), fileService.TusPatch)
+	files.Delete("/tus/:id", middleware.AuthRequired(), fileService.TusDelete)
 }
@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"crud-app/config"
+)
+
+// Result adalah hasil pemindaian satu file
+type Result struct {
+	Clean     bool
+	Signature string // nama malware yang terdeteksi, kosong jika bersih
+}
+
+// Scanner memindai isi file yang di-upload sebelum file boleh diakses
+type Scanner interface {
+	Name() string
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// Nama driver scanner
+const (
+	NameNoop  = "noop"
+	NameClamd = "clamd"
+)
+
+// NewFromEnv membuat scanner dari environment:
+//
+//	SCANNER_DRIVER         noop (default) atau clamd
+//	CLAMD_ADDRESS          tcp://host:port atau unix:///path/clamd.sock (default tcp://localhost:3310)
+//	CLAMD_TIMEOUT_SECONDS  batas waktu satu pemindaian (default 60)
+func NewFromEnv() (Scanner, error) {
+	switch driver := config.GetEnv("SCANNER_DRIVER", NameNoop); driver {
+	case NameNoop:
+		return NewNoop(), nil
+	case NameClamd:
+		network, address, err := parseClamdAddress(config.GetEnv("CLAMD_ADDRESS", "tcp://localhost:3310"))
+		if err != nil {
+			return nil, err
+		}
+		timeout := time.Duration(config.GetEnvInt("CLAMD_TIMEOUT_SECONDS", 60)) * time.Second
+		return NewClamd(network, address, timeout), nil
+	default:
+		return nil, fmt.Errorf("SCANNER_DRIVER %q tidak dikenal (noop atau clamd)", driver)
+	}
+}
+
+func parseClamdAddress(addr string) (string, string, error) {
+	network, address, ok := strings.Cut(addr, "://")
+	if !ok || address == "" || (network != "tcp" && network != "unix") {
+		return "", "", fmt.Errorf("CLAMD_ADDRESS %q tidak valid, gunakan tcp://host:port atau unix:///path", addr)
+	}
+	return network, address, nil
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"crud-app/config"
+)
+
+// Manager menyimpan semua backend yang aktif. Upload baru masuk ke backend
+// default, sedangkan file lama tetap dibaca dari backend yang tercatat di metadata.
+type Manager struct {
+	backends map[string]Storage
+	def      Storage
+}
+
+func NewManager(def Storage, others ...Storage) *Manager {
+	m := &Manager{backends: map[string]Storage{def.Name(): def}, def: def}
+	for _, s := range others {
+		m.backends[s.Name()] = s
+	}
+	return m
+}
+
+// Default adalah backend untuk upload baru
+func (m *Manager) Default() Storage {
+	return m.def
+}
+
+// Backend mengembalikan backend berdasarkan nama yang tercatat di file
+func (m *Manager) Backend(name string) (Storage, error) {
+	if s, ok := m.backends[name]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("backend storage %q tidak dikonfigurasi", name)
+}
+
+// Backends mengembalikan semua backend yang aktif, diurutkan berdasarkan nama
+func (m *Manager) Backends() []Storage {
+	list := make([]Storage, 0, len(m.backends))
+	for _, s := range m.backends {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list
+}
+
+// NewFromEnv membuat Manager dari environment:
+//
+//	STORAGE_DRIVER      local (default) atau s3
+//	STORAGE_LOCAL_PATH  direktori backend local (default ./uploads)
+//	S3_ENDPOINT, S3_ACCESS_KEY, S3_SECRET_KEY, S3_BUCKET, S3_REGION,
+//	S3_USE_SSL (default false), S3_CREATE_BUCKET (default false)
+//
+// Backend local selalu aktif agar file yang sudah ada di disk tetap bisa dibaca.
+// Backend s3 aktif jika S3_ENDPOINT diisi.
+func NewFromEnv() (*Manager, error) {
+	local, err := NewLocal(config.GetEnv("STORAGE_LOCAL_PATH", "./uploads"))
+	if err != nil {
+		return nil, err
+	}
+
+	var s3 *S3
+	if endpoint := config.GetEnv("S3_ENDPOINT", ""); endpoint != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		s3, err = NewS3(ctx, S3Config{
+			Endpoint:     endpoint,
+			AccessKey:    config.GetEnv("S3_ACCESS_KEY", ""),
+			SecretKey:    config.GetEnv("S3_SECRET_KEY", ""),
+			Bucket:       config.GetEnv("S3_BUCKET", ""),
+			Region:       config.GetEnv("S3_REGION", ""),
+			UseSSL:       envBool("S3_USE_SSL", false),
+			CreateBucket: envBool("S3_CREATE_BUCKET", false),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("gagal inisialisasi storage s3: %w", err)
+		}
+	}
+
+	switch driver := config.GetEnv("STORAGE_DRIVER", NameLocal); driver {
+	case NameLocal:
+		if s3 != nil {
+			return NewManager(local, s3), nil
+		}
+		return NewManager(local), nil
+	case NameS3:
+		if s3 == nil {
+			return nil, fmt.Errorf("STORAGE_DRIVER=s3 membutuhkan S3_ENDPOINT")
+		}
+		return NewManager(s3, local), nil
+	default:
+		return nil, fmt.Errorf("STORAGE_DRIVER %q tidak dikenal (local atau s3)", driver)
+	}
+}
+
+func envBool(key string, fallback bool) bool {
+	v, err := strconv.ParseBool(config.GetEnv(key, strconv.FormatBool(fallback)))
+	if err != nil {
+		return fallback
+	}
+	return v
+}
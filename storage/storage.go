@@ -0,0 +1,44 @@
+// Package storage menyimpan isi file upload di backend yang bisa diganti
+// (disk lokal atau object storage S3-compatible seperti MinIO).
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	// ErrNotFound dikembalikan jika objek dengan key tersebut tidak ada
+	ErrNotFound = errors.New("objek tidak ditemukan di storage")
+	// ErrInvalidKey dikembalikan untuk key kosong atau yang keluar dari root storage
+	ErrInvalidKey = errors.New("key storage tidak valid")
+	// ErrPresignNotSupported dikembalikan oleh backend yang tidak bisa membuat URL langsung
+	ErrPresignNotSupported = errors.New("backend storage tidak mendukung presigned URL")
+)
+
+// ObjectInfo adalah metadata objek yang tersimpan
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string // kosong jika backend tidak menyimpan content type
+	ETag        string // tanpa tanda kutip
+	ModTime     time.Time
+}
+
+// Storage adalah driver penyimpanan objek. Key memakai pemisah "/" di semua backend.
+type Storage interface {
+	// Name adalah nama backend yang dicatat di models.File.StorageBackend
+	Name() string
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (ObjectInfo, error)
+	// Get membuka objek untuk dibaca; pemanggil wajib menutupnya
+	Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Delete tidak mengembalikan error jika objek memang sudah tidak ada
+	Delete(ctx context.Context, key string) error
+	// List memanggil fn untuk setiap objek dengan awalan prefix ("" untuk semua objek)
+	List(ctx context.Context, prefix string, fn func(ObjectInfo) error) error
+	// Presign membuat URL GET sementara; downloadName dipakai untuk Content-Disposition
+	Presign(ctx context.Context, key string, expiry time.Duration, downloadName string) (string, error)
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"log/slog"
+	"strings"
+
+	"crud-app/config"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerCarrier membaca dan menulis header request Fiber untuk propagator
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return strings.Clone(h.c.Get(key))
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h.c.GetReqHeaders()))
+	for key := range h.c.GetReqHeaders() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Middleware membuat span server untuk setiap request HTTP, melanjutkan trace dari
+// header traceparent/tracestate client (W3C). Span disimpan di context request sehingga
+// span service dan command MongoDB menjadi anaknya, dan trace_id/span_id ikut tercatat
+// di log. Dipasang setelah RequestID dan sebelum AccessLog; template route, status final
+// dan error handler diambil dari middleware.HandlerResult.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c})
+		ctx, span := Start(ctx, c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			// Nilai dari Fiber disalin karena buffernya dipakai ulang setelah request selesai
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(strings.Clone(c.Path())),
+				semconv.ClientAddress(strings.Clone(c.IP())),
+				semconv.UserAgentOriginal(strings.Clone(c.Get(fiber.HeaderUserAgent))),
+			),
+		)
+		defer span.End()
+
+		if requestID, ok := c.Locals("request_id").(string); ok {
+			span.SetAttributes(attribute.String("request_id", requestID))
+		}
+		if sc := span.SpanContext(); sc.IsValid() {
+			ctx = config.WithLogAttrs(ctx,
+				slog.String("trace_id", sc.TraceID().String()),
+				slog.String("span_id", sc.SpanID().String()),
+			)
+		}
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		if route, ok := c.Locals("route").(string); ok {
+			span.SetAttributes(semconv.HTTPRoute(route))
+			span.SetName(c.Method() + " " + route)
+		}
+		status := c.Response().StatusCode()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if handlerErr, ok := c.Locals("error").(error); ok {
+			span.RecordError(handlerErr)
+		}
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+		return err
+	}
+}
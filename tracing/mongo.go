@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"crud-app/utils"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewMongoMonitor membuat CommandMonitor yang membuat span client untuk setiap command
+// MongoDB (find, aggregate, ...). Span menjadi anak span di context operasi, sehingga
+// $lookup yang lambat terlihat terpisah dari waktu handler.
+func NewMongoMonitor() *event.CommandMonitor {
+	var inFlight sync.Map // request ID command -> trace.Span
+
+	finish := func(requestID int64, failure string) {
+		value, ok := inFlight.LoadAndDelete(requestID)
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		if failure != "" {
+			span.SetStatus(codes.Error, failure)
+		}
+		span.End()
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			if utils.IsInternalMongoCommand(evt.CommandName) {
+				return
+			}
+			collection := utils.MongoCommandCollection(evt.CommandName, evt.Command)
+			name := evt.CommandName
+			if collection != "" {
+				name += " " + collection
+			}
+			_, span := Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.DBSystemNameMongoDB,
+					semconv.DBNamespace(evt.DatabaseName),
+					semconv.DBCollectionName(collection),
+					semconv.DBOperationName(evt.CommandName),
+				),
+			)
+			inFlight.Store(evt.RequestID, span)
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.RequestID, "")
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.RequestID, evt.Failure)
+		},
+	}
+}
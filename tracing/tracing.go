@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"crud-app/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Nama instrumentation untuk semua span aplikasi
+const instrumentationName = "crud-app"
+
+// Exporter yang didukung OTEL_TRACES_EXPORTER
+const (
+	ExporterNone    = "none"
+	ExporterOTLP    = "otlp"
+	ExporterConsole = "console"
+	ExporterStdout  = "stdout"
+)
+
+// InitFromEnv memasang TracerProvider global sesuai OTEL_TRACES_EXPORTER:
+//   - none (default): span tidak diekspor, trace context dari client tetap diteruskan
+//   - otlp: dikirim ke collector lewat OTLP; endpoint, header dan protokol
+//     (http/protobuf atau grpc) diatur lewat variabel OTEL_EXPORTER_OTLP_* standar
+//   - console / stdout: span ditulis ke stdout untuk pengembangan lokal
+//
+// Nama service default "crud-app" (OTEL_SERVICE_NAME / OTEL_RESOURCE_ATTRIBUTES
+// didahulukan) dan sampler mengikuti OTEL_TRACES_SAMPLER. Propagasi memakai W3C
+// trace context dan baggage. Fungsi yang dikembalikan mengirim sisa span saat aplikasi berhenti.
+func InitFromEnv(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	driver := config.GetEnv("OTEL_TRACES_EXPORTER", ExporterNone)
+	if driver == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, driver)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(instrumentationName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("resource tracing: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, driver string) (sdktrace.SpanExporter, error) {
+	switch driver {
+	case ExporterOTLP:
+		protocol := config.GetEnv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", config.GetEnv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf"))
+		switch protocol {
+		case "http/protobuf":
+			return otlptracehttp.New(ctx)
+		case "grpc":
+			return otlptracegrpc.New(ctx)
+		default:
+			return nil, fmt.Errorf("OTEL_EXPORTER_OTLP_PROTOCOL %q tidak didukung (http/protobuf atau grpc)", protocol)
+		}
+	case ExporterConsole, ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("OTEL_TRACES_EXPORTER %q tidak dikenal (none, otlp atau console)", driver)
+	}
+}
+
+// Start membuat span anak dari span yang ada di ctx
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
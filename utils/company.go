@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Bentuk badan usaha yang diabaikan saat menormalisasi nama perusahaan
+var companyLegalTokens = map[string]bool{
+	"pt": true, "cv": true, "tbk": true, "persero": true, "ud": true, "pd": true,
+	"inc": true, "ltd": true, "llc": true, "corp": true, "corporation": true, "limited": true,
+}
+
+// NormalizeCompanyName menyeragamkan nama perusahaan untuk pencocokan:
+// huruf kecil, tanpa tanda baca dan tanpa bentuk badan usaha.
+// "PT. TELKOM", "PT Telkom" dan "Telkom (Persero) Tbk" menjadi "telkom".
+func NormalizeCompanyName(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, name)
+
+	var tokens []string
+	for _, token := range strings.Fields(cleaned) {
+		if !companyLegalTokens[token] {
+			tokens = append(tokens, token)
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// CompanyNameSimilarity menghitung kemiripan dua nama yang sudah dinormalisasi (0..1)
+// berdasarkan token yang sama; nama yang seluruh tokennya termuat di nama lain
+// (mis. "telkom" dan "telkom indonesia") mendapat skor minimal 0.75.
+func CompanyNameSimilarity(a, b string) float64 {
+	tokensA, tokensB := strings.Fields(a), strings.Fields(b)
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		return 0
+	}
+
+	setB := make(map[string]bool, len(tokensB))
+	for _, t := range tokensB {
+		setB[t] = true
+	}
+
+	common := 0
+	seen := make(map[string]bool, len(tokensA))
+	for _, t := range tokensA {
+		if setB[t] && !seen[t] {
+			common++
+		}
+		seen[t] = true
+	}
+	union := len(seen) + len(setB) - common
+	score := float64(common) / float64(union)
+
+	smaller := len(seen)
+	if len(setB) < smaller {
+		smaller = len(setB)
+	}
+	if common == smaller && score < 0.75 {
+		score = 0.75
+	}
+	return score
+}
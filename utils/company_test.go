@@ -0,0 +1,49 @@
+package utils
+
+import "testing"
+
+func TestNormalizeCompanyName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"PT. TELKOM", "telkom"},
+		{"PT Telkom", "telkom"},
+		{"Telkom (Persero) Tbk", "telkom"},
+		{"PT Bank Central Asia, Tbk.", "bank central asia"},
+		{"Google LLC", "google"},
+		{"CV. Maju-Jaya", "maju jaya"},
+		{"  PT  ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeCompanyName(tt.name); got != tt.want {
+				t.Fatalf("NormalizeCompanyName(%q) = %q, ingin %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompanyNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{"telkom", "telkom", 1, 1},
+		{"telkom", "telkom indonesia", 0.75, 1},
+		{"bank central asia", "bank rakyat indonesia", 0, 0.5},
+		{"telkom", "", 0, 0},
+		{"gojek", "tokopedia", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"|"+tt.b, func(t *testing.T) {
+			got := CompanyNameSimilarity(tt.a, tt.b)
+			if got < tt.min || got > tt.max {
+				t.Fatalf("CompanyNameSimilarity(%q, %q) = %v, ingin %v..%v", tt.a, tt.b, got, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Format tanggal date-only yang dipakai saat menyimpan / mengirim tanggal sebagai string
+const DateLayout = "2006-01-02"
+
+// Format tanggal yang diterima dari client, dicoba berurutan
+var dateLayouts = []string{
+	DateLayout,
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"02-01-2006",
+	"02/01/2006",
+	"2006/01/02",
+}
+
+// ParseDate mengubah string tanggal menjadi time.Time date-only (00:00 UTC)
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return TruncateDate(t), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("format tanggal '%s' tidak dikenali, gunakan YYYY-MM-DD", value)
+}
+
+// TruncateDate membuang komponen jam sehingga hanya tersisa tanggal (UTC)
+func TruncateDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Today mengembalikan tanggal hari ini (date-only, UTC)
+func Today() time.Time {
+	return TruncateDate(time.Now())
+}
+
+// GraduationMonth adalah bulan kelulusan yang diasumsikan. tahun_lulus hanya
+// menyimpan tahun, sehingga tengah tahun dipakai agar selisihnya maksimal 6 bulan.
+const GraduationMonth = time.July
+
+// GraduationDate mengembalikan perkiraan tanggal lulus dari tahun_lulus
+func GraduationDate(year int) time.Time {
+	return time.Date(year, GraduationMonth, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// MonthsBetween menghitung jumlah bulan kalender penuh dari start ke end
+func MonthsBetween(start, end time.Time) int {
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+	return months
+}
+
+// DaysBetween menghitung jumlah hari dari start ke end
+func DaysBetween(start, end time.Time) int {
+	return int(TruncateDate(end).Sub(TruncateDate(start)).Hours() / 24)
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // decoder untuk validasi JPEG
+	_ "image/png"  // decoder untuk validasi PNG
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Batas dimensi gambar yang mau di-decode, mencegah decompression bomb
+const MaxImagePixels = 40_000_000
+
+// Ekstensi yang disimpan untuk setiap content type hasil deteksi
+var extensionByType = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+// NormalizeContentType menyeragamkan content type dari client
+// (huruf kecil, tanpa parameter, alias image/jpg menjadi image/jpeg)
+func NormalizeContentType(contentType string) string {
+	ct, _, _ := strings.Cut(contentType, ";")
+	ct = strings.ToLower(strings.TrimSpace(ct))
+	if ct == "image/jpg" || ct == "image/pjpeg" {
+		return "image/jpeg"
+	}
+	return ct
+}
+
+// DetectContentType menentukan content type dari isi file (magic bytes),
+// lalu mengembalikan posisi reader ke awal
+func DetectContentType(r io.ReadSeeker) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return NormalizeContentType(http.DetectContentType(head[:n])), nil
+}
+
+// ExtensionForContentType mengembalikan ekstensi file untuk content type hasil deteksi
+func ExtensionForContentType(contentType string) string {
+	return extensionByType[contentType]
+}
+
+// ValidateFileStructure mem-parsing isi file sesuai content type-nya untuk memastikan
+// file benar-benar valid, bukan hanya magic bytes-nya. Reader dikembalikan ke awal.
+func ValidateFileStructure(r io.ReadSeeker, size int64, contentType string) error {
+	var err error
+	switch contentType {
+	case "image/jpeg", "image/png":
+		err = validateImage(r)
+	case "application/pdf":
+		err = validatePDF(r, size)
+	default:
+		return fmt.Errorf("content type %s tidak didukung", contentType)
+	}
+	if _, seekErr := r.Seek(0, io.SeekStart); err == nil {
+		err = seekErr
+	}
+	return err
+}
+
+// validateImage hanya membaca header gambar dan membatasi dimensinya. Isi gambar
+// di-decode penuh sekali oleh ProcessPhoto, yang menolak gambar rusak.
+func validateImage(r io.ReadSeeker) error {
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return fmt.Errorf("gambar tidak valid: %v", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > MaxImagePixels {
+		return fmt.Errorf("dimensi gambar %dx%d tidak diizinkan", cfg.Width, cfg.Height)
+	}
+	return nil
+}
+
+var (
+	pdfHeaderPattern    = regexp.MustCompile(`^%PDF-[12]\.\d`)
+	pdfStartXrefPattern = regexp.MustCompile(`startxref\s+(\d+)\s+%%EOF\s*$`)
+	pdfXrefPattern      = regexp.MustCompile(`^\s*(xref|\d+\s+\d+\s+obj)`)
+)
+
+// validatePDF mengecek struktur dasar PDF: header versi, trailer "startxref N %%EOF"
+// di akhir file, dan offset N yang menunjuk ke tabel xref atau xref stream.
+func validatePDF(r io.ReadSeeker, size int64) error {
+	head := make([]byte, 16)
+	if _, err := io.ReadFull(r, head); err != nil || !pdfHeaderPattern.Match(head) {
+		return errors.New("PDF tidak valid: header %PDF tidak ditemukan")
+	}
+
+	tailSize := int64(2048)
+	if size < tailSize {
+		tailSize = size
+	}
+	if _, err := r.Seek(size-tailSize, io.SeekStart); err != nil {
+		return err
+	}
+	tail := make([]byte, tailSize)
+	if _, err := io.ReadFull(r, tail); err != nil {
+		return fmt.Errorf("PDF tidak valid: %v", err)
+	}
+
+	// Incremental update bisa menambah beberapa trailer; yang terakhir yang berlaku
+	idx := bytes.LastIndex(tail, []byte("startxref"))
+	if idx < 0 {
+		return errors.New("PDF tidak valid: startxref tidak ditemukan")
+	}
+	m := pdfStartXrefPattern.FindSubmatch(tail[idx:])
+	if m == nil {
+		return errors.New("PDF tidak valid: trailer %%EOF tidak lengkap")
+	}
+	offset, err := strconv.ParseInt(string(m[1]), 10, 64)
+	if err != nil || offset <= 0 || offset >= size {
+		return errors.New("PDF tidak valid: offset xref di luar file")
+	}
+
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	xref := make([]byte, 32)
+	n, _ := io.ReadFull(r, xref)
+	if !pdfXrefPattern.Match(xref[:n]) {
+		return errors.New("PDF tidak valid: tabel xref tidak ditemukan")
+	}
+	return nil
+}
+
+// HashSHA256 menghitung hash SHA-256 (hex) seluruh isi reader,
+// lalu mengembalikan posisi reader ke awal
+func HashSHA256(r io.ReadSeeker) (string, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
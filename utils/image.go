@@ -0,0 +1,265 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+)
+
+// Dimensi maksimal (sisi terpanjang) foto yang disimpan
+const MaxPhotoDimension = 1600
+
+// Kualitas encode JPEG untuk foto dan thumbnail
+const photoJPEGQuality = 85
+
+// PhotoVariant adalah ukuran thumbnail yang dibuat saat foto di-upload
+type PhotoVariant struct {
+	Name         string
+	MaxDimension int
+}
+
+// Thumbnail yang dibuat untuk setiap foto, dari yang terkecil
+var PhotoVariants = []PhotoVariant{
+	{Name: "small", MaxDimension: 128},
+	{Name: "medium", MaxDimension: 320},
+	{Name: "large", MaxDimension: 800},
+}
+
+// IsPhotoVariant mengecek apakah name adalah ukuran thumbnail yang dikenal
+func IsPhotoVariant(name string) bool {
+	for _, v := range PhotoVariants {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ProcessedImage adalah hasil encode ulang satu gambar
+type ProcessedImage struct {
+	Data   []byte
+	Width  int
+	Height int
+}
+
+// ProcessPhoto men-decode foto, memutarnya sesuai EXIF orientation, mengecilkannya ke
+// MaxPhotoDimension lalu meng-encode ulang dengan format yang sama. Encode ulang
+// membuang seluruh metadata (EXIF, termasuk lokasi GPS). Thumbnail dikembalikan per nama varian.
+func ProcessPhoto(r io.ReadSeeker, contentType string) (*ProcessedImage, map[string]*ProcessedImage, error) {
+	orientation := readOrientation(r, contentType)
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("gambar tidak valid: %v", err)
+	}
+	img = resizeToFit(applyOrientation(img, orientation), MaxPhotoDimension)
+
+	photo, err := encodeImage(img, contentType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variants := make(map[string]*ProcessedImage, len(PhotoVariants))
+	for _, v := range PhotoVariants {
+		thumb, err := encodeImage(resizeToFit(img, v.MaxDimension), contentType)
+		if err != nil {
+			return nil, nil, err
+		}
+		variants[v.Name] = thumb
+	}
+	return photo, variants, nil
+}
+
+func encodeImage(img image.Image, contentType string) (*ProcessedImage, error) {
+	var buf bytes.Buffer
+	var err error
+	switch contentType {
+	case "image/jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: photoJPEGQuality})
+	case "image/png":
+		err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, img)
+	default:
+		return nil, fmt.Errorf("content type %s tidak didukung", contentType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	b := img.Bounds()
+	return &ProcessedImage{Data: buf.Bytes(), Width: b.Dx(), Height: b.Dy()}, nil
+}
+
+// resizeToFit mengecilkan gambar agar sisi terpanjangnya maksimal maxDim (tidak pernah memperbesar)
+func resizeToFit(img image.Image, maxDim int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxDim && h <= maxDim {
+		return img
+	}
+
+	nw, nh := maxDim, maxDim
+	if w > h {
+		nh = max(1, h*maxDim/w)
+	} else {
+		nw = max(1, w*maxDim/h)
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, nw, nh))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// applyOrientation memutar / membalik gambar sesuai nilai EXIF orientation (1-8)
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	src := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	w, h := b.Dx(), b.Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // cermin horizontal
+				sx, sy = w-1-x, y
+			case 3: // putar 180
+				sx, sy = w-1-x, h-1-y
+			case 4: // cermin vertikal
+				sx, sy = x, h-1-y
+			case 5: // transpose
+				sx, sy = y, x
+			case 6: // putar 90 searah jarum jam
+				sx, sy = y, h-1-x
+			case 7: // transverse
+				sx, sy = w-1-y, h-1-x
+			case 8: // putar 90 berlawanan jarum jam
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
+
+// readOrientation membaca tag EXIF orientation dari segmen APP1 (JPEG) atau chunk eXIf (PNG).
+// Mengembalikan 1 (normal) jika tidak ada atau tidak terbaca.
+func readOrientation(r io.Reader, contentType string) int {
+	var tiff []byte
+	switch contentType {
+	case "image/jpeg":
+		tiff = jpegExif(r)
+	case "image/png":
+		tiff = pngExif(r)
+	}
+	if o := tiffOrientation(tiff); o != 0 {
+		return o
+	}
+	return 1
+}
+
+// jpegExif mencari segmen APP1 "Exif" sebelum data gambar (SOS)
+func jpegExif(r io.Reader) []byte {
+	var soi [2]byte
+	if _, err := io.ReadFull(r, soi[:]); err != nil || soi != [2]byte{0xFF, 0xD8} {
+		return nil
+	}
+	for {
+		var marker [4]byte
+		if _, err := io.ReadFull(r, marker[:]); err != nil || marker[0] != 0xFF {
+			return nil
+		}
+		if marker[1] == 0xDA || marker[1] == 0xD9 { // SOS / EOI
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(marker[2:])) - 2
+		if length < 0 {
+			return nil
+		}
+		data := make([]byte, length)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil
+		}
+		if marker[1] == 0xE1 && bytes.HasPrefix(data, []byte("Exif\x00\x00")) {
+			return data[6:]
+		}
+	}
+}
+
+// pngExif mencari chunk eXIf sebelum data gambar (IDAT)
+func pngExif(r io.Reader) []byte {
+	var sig [8]byte
+	if _, err := io.ReadFull(r, sig[:]); err != nil || string(sig[:]) != "\x89PNG\r\n\x1a\n" {
+		return nil
+	}
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return nil
+		}
+		length := binary.BigEndian.Uint32(header[:4])
+		chunkType := string(header[4:])
+		if chunkType == "IDAT" || chunkType == "IEND" || length > 1<<24 {
+			return nil
+		}
+		data := make([]byte, length+4) // data + CRC
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil
+		}
+		if chunkType == "eXIf" {
+			return data[:length]
+		}
+	}
+}
+
+// tiffOrientation membaca tag 0x0112 (Orientation) dari IFD0 data TIFF/EXIF
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			o := int(order.Uint16(tiff[entry+8:]))
+			if o >= 1 && o <= 8 {
+				return o
+			}
+			return 0
+		}
+	}
+	return 0
+}
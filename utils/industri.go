@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	models "crud-app/app/model"
+)
+
+// normalizeIndustriText menyeragamkan teks untuk pencocokan: huruf kecil,
+// tanda baca menjadi spasi, spasi berlebih dibuang.
+func normalizeIndustriText(text string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, text)
+	return strings.Join(strings.Fields(cleaned), " ")
+}
+
+// MatchIndustri memetakan bidang_industri teks bebas ke salah satu kode taksonomi.
+// Judul yang sama persis menang (kode paling spesifik jika judulnya kembar); selain itu
+// dipilih kata kunci terpanjang yang muncul utuh di teks, dan jika sama panjang kode
+// yang lebih spesifik. Nil jika tidak ada yang cocok.
+func MatchIndustri(text string, list []models.Industri) *models.Industri {
+	normal := normalizeIndustriText(text)
+	if normal == "" {
+		return nil
+	}
+	padded := " " + normal + " "
+
+	var best, exact *models.Industri
+	bestLen := 0
+	for i := range list {
+		if normalizeIndustriText(list[i].Judul) == normal {
+			if exact == nil || len(list[i].Kode) > len(exact.Kode) {
+				exact = &list[i]
+			}
+			continue
+		}
+		for _, kataKunci := range list[i].KataKunci {
+			kw := normalizeIndustriText(kataKunci)
+			if kw == "" || !strings.Contains(padded, " "+kw+" ") {
+				continue
+			}
+			if len(kw) > bestLen || (len(kw) == bestLen && best != nil && len(list[i].Kode) > len(best.Kode)) {
+				best = &list[i]
+				bestLen = len(kw)
+			}
+		}
+	}
+	if exact != nil {
+		return exact
+	}
+	return best
+}
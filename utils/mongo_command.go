@@ -0,0 +1,39 @@
+package utils
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// Command handshake dan sesi yang bukan operasi aplikasi
+var internalMongoCommands = map[string]bool{
+	"hello":        true,
+	"isMaster":     true,
+	"ismaster":     true,
+	"ping":         true,
+	"endSessions":  true,
+	"saslStart":    true,
+	"saslContinue": true,
+}
+
+// IsInternalMongoCommand melaporkan apakah command MongoDB berasal dari driver
+// (handshake, ping, sesi, autentikasi) dan tidak perlu dicatat di metric atau trace
+func IsInternalMongoCommand(name string) bool {
+	return internalMongoCommands[name]
+}
+
+// MongoCommandCollection mengambil nama collection dari command: nilai elemen pertama
+// (find: "files", aggregate: "files", ...) atau field "collection" untuk getMore
+func MongoCommandCollection(name string, command bson.Raw) string {
+	if name == "getMore" {
+		if value, err := command.LookupErr("collection"); err == nil {
+			if coll, ok := value.StringValueOK(); ok {
+				return coll
+			}
+		}
+		return ""
+	}
+	if value, err := command.LookupErr(name); err == nil {
+		if coll, ok := value.StringValueOK(); ok {
+			return coll
+		}
+	}
+	return ""
+}
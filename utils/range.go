@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrRangeNotSatisfiable dikembalikan jika header Range valid tapi di luar ukuran file (HTTP 416)
+var ErrRangeNotSatisfiable = errors.New("range tidak dapat dipenuhi")
+
+// ByteRange adalah satu rentang byte hasil parsing header Range
+type ByteRange struct {
+	Start  int64
+	Length int64
+}
+
+// End mengembalikan offset byte terakhir (inklusif) untuk header Content-Range
+func (r ByteRange) End() int64 {
+	return r.Start + r.Length - 1
+}
+
+// ParseRange mem-parsing header Range (RFC 7233) untuk file berukuran size.
+// Hanya satu rentang yang didukung: "bytes=0-99", "bytes=100-" atau "bytes=-100".
+// Nil tanpa error berarti header kosong, tidak valid, bukan satuan bytes atau
+// berisi banyak rentang; header seperti itu diabaikan dan seluruh file dikirim.
+func ParseRange(header string, size int64) (*ByteRange, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil, nil
+	}
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return nil, nil
+	}
+
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return nil, nil
+	}
+	startStr, endStr = strings.TrimSpace(startStr), strings.TrimSpace(endStr)
+
+	// Suffix range: n byte terakhir
+	if startStr == "" {
+		n, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || n < 0 {
+			return nil, nil
+		}
+		if n == 0 || size == 0 {
+			return nil, ErrRangeNotSatisfiable
+		}
+		if n > size {
+			n = size
+		}
+		return &ByteRange{Start: size - n, Length: n}, nil
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return nil, nil
+	}
+	if start >= size {
+		return nil, ErrRangeNotSatisfiable
+	}
+
+	end := size - 1
+	if endStr != "" {
+		end, err = strconv.ParseInt(endStr, 10, 64)
+		if err != nil || end < start {
+			return nil, nil
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	return &ByteRange{Start: start, Length: end - start + 1}, nil
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	const size = 1000
+
+	tests := []struct {
+		name    string
+		header  string
+		size    int64
+		want    *ByteRange
+		wantErr error
+	}{
+		{name: "kosong", header: "", size: size},
+		{name: "rentang tertutup", header: "bytes=0-99", size: size, want: &ByteRange{Start: 0, Length: 100}},
+		{name: "spasi diabaikan", header: " bytes= 10 - 19 ", size: size, want: &ByteRange{Start: 10, Length: 10}},
+		{name: "terbuka", header: "bytes=900-", size: size, want: &ByteRange{Start: 900, Length: 100}},
+		{name: "suffix", header: "bytes=-100", size: size, want: &ByteRange{Start: 900, Length: 100}},
+		{name: "suffix melebihi ukuran", header: "bytes=-5000", size: size, want: &ByteRange{Start: 0, Length: size}},
+		{name: "suffix nol", header: "bytes=-0", size: size, wantErr: ErrRangeNotSatisfiable},
+		{name: "suffix file kosong", header: "bytes=-10", size: 0, wantErr: ErrRangeNotSatisfiable},
+		{name: "akhir melewati EOF dipotong", header: "bytes=500-5000", size: size, want: &ByteRange{Start: 500, Length: 500}},
+		{name: "awal di EOF", header: "bytes=1000-", size: size, wantErr: ErrRangeNotSatisfiable},
+		{name: "awal melewati EOF", header: "bytes=2000-2999", size: size, wantErr: ErrRangeNotSatisfiable},
+		{name: "byte terakhir", header: "bytes=999-999", size: size, want: &ByteRange{Start: 999, Length: 1}},
+		{name: "banyak rentang diabaikan", header: "bytes=0-9,20-29", size: size},
+		{name: "satuan lain diabaikan", header: "items=0-9", size: size},
+		{name: "akhir sebelum awal diabaikan", header: "bytes=50-10", size: size},
+		{name: "tanpa tanda hubung diabaikan", header: "bytes=10", size: size},
+		{name: "angka tidak valid diabaikan", header: "bytes=a-b", size: size},
+		{name: "awal negatif diabaikan", header: "bytes=--5", size: size},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRange(tt.header, tt.size)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, ingin %v", err, tt.wantErr)
+			}
+			switch {
+			case tt.want == nil && got != nil:
+				t.Fatalf("range = %+v, ingin nil", *got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Fatalf("range = %+v, ingin %+v", got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestByteRangeEnd(t *testing.T) {
+	r := ByteRange{Start: 900, Length: 100}
+	if got := r.End(); got != 999 {
+		t.Fatalf("End() = %d, ingin 999", got)
+	}
+}
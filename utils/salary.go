@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	models "crud-app/app/model"
+)
+
+var (
+	salaryNumberRegex   = regexp.MustCompile(`(\d+(?:[.,]\d+)*)\s*(miliar|milyar|juta|jt|ribu|rb|k)?`)
+	salaryThousandRegex = regexp.MustCompile(`^\d{1,3}([.,]\d{3})+$`)
+)
+
+// Kata kunci penanda batas bawah / batas atas terbuka
+var (
+	salaryLowerBoundPrefixes = []string{">=", ">", "lebih dari", "di atas", "diatas", "minimal", "min", "mulai"}
+	salaryUpperBoundPrefixes = []string{"<=", "<", "kurang dari", "di bawah", "dibawah", "maksimal", "max", "hingga", "sampai"}
+)
+
+// ParseSalaryRange mengubah teks gaji bebas (mis. "5-7 juta", "Rp 5.000.000 - Rp 7.000.000",
+// "> 10 jt", "USD 1000-2000 per tahun") menjadi models.Gaji.
+// Angka tanpa satuan di bawah 1000 untuk IDR dianggap dalam juta.
+func ParseSalaryRange(text string) (models.Gaji, error) {
+	gaji := models.Gaji{GajiMataUang: "IDR", GajiPeriode: models.GajiPeriodeBulanan}
+
+	s := strings.ToLower(strings.TrimSpace(text))
+	if s == "" {
+		return gaji, fmt.Errorf("gaji kosong")
+	}
+
+	switch {
+	case strings.Contains(s, "usd") || strings.Contains(s, "$"):
+		gaji.GajiMataUang = "USD"
+	case strings.Contains(s, "sgd"):
+		gaji.GajiMataUang = "SGD"
+	}
+	if strings.Contains(s, "tahun") || strings.Contains(s, "thn") || strings.Contains(s, "/th") ||
+		strings.Contains(s, "annual") || strings.Contains(s, "year") {
+		gaji.GajiPeriode = models.GajiPeriodeTahunan
+	}
+
+	lowerOnly := strings.HasSuffix(s, "+")
+	upperOnly := false
+	cleaned := strings.TrimSpace(strings.NewReplacer("rp.", "", "rp", "", "idr", "", "usd", "", "sgd", "", "$", "").Replace(s))
+	for _, prefix := range salaryLowerBoundPrefixes {
+		if strings.HasPrefix(cleaned, prefix) {
+			lowerOnly = true
+			break
+		}
+	}
+	for _, prefix := range salaryUpperBoundPrefixes {
+		if strings.HasPrefix(cleaned, prefix) {
+			upperOnly = true
+			break
+		}
+	}
+
+	matches := salaryNumberRegex.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 {
+		return gaji, fmt.Errorf("tidak ada angka gaji pada '%s'", text)
+	}
+	if len(matches) > 2 {
+		matches = matches[:2]
+	}
+
+	values := make([]float64, len(matches))
+	units := make([]float64, len(matches))
+	for i, m := range matches {
+		v, err := parseSalaryNumber(m[1])
+		if err != nil {
+			return gaji, fmt.Errorf("angka gaji '%s' tidak valid", m[1])
+		}
+		values[i] = v
+		units[i] = salaryUnitMultiplier(m[2])
+	}
+
+	// "5-7 juta": satuan angka terakhir berlaku juga untuk angka sebelumnya
+	if len(units) == 2 && units[0] == 1 && units[1] != 1 {
+		units[0] = units[1]
+	}
+
+	amounts := make([]int64, len(values))
+	for i := range values {
+		unit := units[i]
+		if unit == 1 && gaji.GajiMataUang == "IDR" && values[i] < 1000 {
+			unit = 1e6
+		}
+		amounts[i] = int64(math.Round(values[i] * unit))
+	}
+
+	switch {
+	case len(amounts) == 2:
+		gaji.GajiMin, gaji.GajiMax = amounts[0], amounts[1]
+	case lowerOnly:
+		gaji.GajiMin = amounts[0]
+	case upperOnly:
+		gaji.GajiMax = amounts[0]
+	default:
+		gaji.GajiMin, gaji.GajiMax = amounts[0], amounts[0]
+	}
+
+	if gaji.GajiMax != 0 && gaji.GajiMin > gaji.GajiMax {
+		gaji.GajiMin, gaji.GajiMax = gaji.GajiMax, gaji.GajiMin
+	}
+	return gaji, nil
+}
+
+// parseSalaryNumber membaca angka dengan pemisah ribuan (5.000.000) atau desimal (5,5 / 5.5)
+func parseSalaryNumber(raw string) (float64, error) {
+	if salaryThousandRegex.MatchString(raw) {
+		raw = strings.NewReplacer(".", "", ",", "").Replace(raw)
+	} else if strings.Count(raw, ".")+strings.Count(raw, ",") > 1 {
+		// Format Indonesia "5.500.000,00": titik ribuan, koma desimal
+		raw = strings.ReplaceAll(raw, ".", "")
+	}
+	return strconv.ParseFloat(strings.ReplaceAll(raw, ",", "."), 64)
+}
+
+func salaryUnitMultiplier(unit string) float64 {
+	switch unit {
+	case "miliar", "milyar":
+		return 1e9
+	case "juta", "jt":
+		return 1e6
+	case "ribu", "rb", "k":
+		return 1e3
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"testing"
+
+	models "crud-app/app/model"
+)
+
+func TestParseSalaryRange(t *testing.T) {
+	bulanan := func(min, max int64) models.Gaji {
+		return models.Gaji{GajiMin: min, GajiMax: max, GajiMataUang: "IDR", GajiPeriode: models.GajiPeriodeBulanan}
+	}
+
+	tests := []struct {
+		text string
+		want models.Gaji
+	}{
+		{"5-7 juta", bulanan(5_000_000, 7_000_000)},
+		{"5 - 7 jt", bulanan(5_000_000, 7_000_000)},
+		{"Rp 5.000.000 - Rp 7.000.000", bulanan(5_000_000, 7_000_000)},
+		{"Rp. 4.500.000", bulanan(4_500_000, 4_500_000)},
+		{"IDR 5.500.000,00", bulanan(5_500_000, 5_500_000)},
+		{"4,5 juta", bulanan(4_500_000, 4_500_000)},
+		{"3.5 - 4.5 juta", bulanan(3_500_000, 4_500_000)},
+		{"500rb - 1 juta", bulanan(500_000, 1_000_000)},
+		{"8000k", bulanan(8_000_000, 8_000_000)},
+		{"5-7", bulanan(5_000_000, 7_000_000)},
+		{"> 10 jt", bulanan(10_000_000, 0)},
+		{"lebih dari 15 juta", bulanan(15_000_000, 0)},
+		{"10 juta+", bulanan(10_000_000, 0)},
+		{"< 3 juta", bulanan(0, 3_000_000)},
+		{"maksimal 4 juta", bulanan(0, 4_000_000)},
+		{"7-5 juta", bulanan(5_000_000, 7_000_000)},
+		{"1,2 miliar per tahun", models.Gaji{GajiMin: 1_200_000_000, GajiMax: 1_200_000_000, GajiMataUang: "IDR", GajiPeriode: models.GajiPeriodeTahunan}},
+		{"USD 1000-2000 per tahun", models.Gaji{GajiMin: 1000, GajiMax: 2000, GajiMataUang: "USD", GajiPeriode: models.GajiPeriodeTahunan}},
+		{"$500", models.Gaji{GajiMin: 500, GajiMax: 500, GajiMataUang: "USD", GajiPeriode: models.GajiPeriodeBulanan}},
+		{"SGD 4000 - 5000", models.Gaji{GajiMin: 4000, GajiMax: 5000, GajiMataUang: "SGD", GajiPeriode: models.GajiPeriodeBulanan}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseSalaryRange(tt.text)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("gaji = %+v, ingin %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSalaryRangeInvalid(t *testing.T) {
+	for _, text := range []string{"", "   ", "negotiable", "sesuai UMR"} {
+		t.Run(text, func(t *testing.T) {
+			if got, err := ParseSalaryRange(text); err == nil {
+				t.Fatalf("ParseSalaryRange(%q) = %+v, ingin error", text, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrLinkSignature dikembalikan jika tanda tangan link tidak cocok
+	ErrLinkSignature = errors.New("tanda tangan link tidak valid")
+	// ErrLinkExpired dikembalikan jika masa berlaku link sudah lewat
+	ErrLinkExpired = errors.New("link sudah kedaluwarsa")
+)
+
+// SignFileLink membuat tanda tangan HMAC-SHA256 untuk link unduh file. userID boleh
+// kosong untuk link yang tidak terikat ke user tertentu.
+func SignFileLink(secret []byte, fileID string, expires int64, userID string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(fileID + "\n" + strconv.FormatInt(expires, 10) + "\n" + userID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyFileLink mengecek tanda tangan dan masa berlaku link unduh file
+func VerifyFileLink(secret []byte, fileID string, expires int64, userID, signature string, now time.Time) error {
+	expected := SignFileLink(secret, fileID, expires, userID)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrLinkSignature
+	}
+	if now.Unix() > expires {
+		return ErrLinkExpired
+	}
+	return nil
+}
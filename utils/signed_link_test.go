@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerifyFileLink(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	now := time.Unix(1_700_000_000, 0)
+	expires := now.Add(time.Hour).Unix()
+	fileID := "652f1c2e9b1e8a0012345678"
+	userID := "652f1c2e9b1e8a0087654321"
+	signature := SignFileLink(secret, fileID, expires, userID)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		fileID    string
+		expires   int64
+		userID    string
+		signature string
+		now       time.Time
+		wantErr   error
+	}{
+		{name: "valid", secret: secret, fileID: fileID, expires: expires, userID: userID, signature: signature, now: now},
+		{name: "tepat saat kedaluwarsa", secret: secret, fileID: fileID, expires: expires, userID: userID, signature: signature, now: time.Unix(expires, 0)},
+		{name: "kedaluwarsa", secret: secret, fileID: fileID, expires: expires, userID: userID, signature: signature, now: time.Unix(expires+1, 0), wantErr: ErrLinkExpired},
+		{name: "expires diubah", secret: secret, fileID: fileID, expires: expires + 3600, userID: userID, signature: signature, now: now, wantErr: ErrLinkSignature},
+		{name: "file lain", secret: secret, fileID: "652f1c2e9b1e8a0000000000", expires: expires, userID: userID, signature: signature, now: now, wantErr: ErrLinkSignature},
+		{name: "user lain", secret: secret, fileID: fileID, expires: expires, userID: "652f1c2e9b1e8a0011111111", signature: signature, now: now, wantErr: ErrLinkSignature},
+		{name: "ikatan user dihapus", secret: secret, fileID: fileID, expires: expires, userID: "", signature: signature, now: now, wantErr: ErrLinkSignature},
+		{name: "kunci lain", secret: []byte("fedcba9876543210fedcba9876543210"), fileID: fileID, expires: expires, userID: userID, signature: signature, now: now, wantErr: ErrLinkSignature},
+		{name: "tanda tangan diubah", secret: secret, fileID: fileID, expires: expires, userID: userID, signature: signature[:len(signature)-1] + "A", now: now, wantErr: ErrLinkSignature},
+		{name: "tanda tangan kosong", secret: secret, fileID: fileID, expires: expires, userID: userID, signature: "", now: now, wantErr: ErrLinkSignature},
+		{name: "kedaluwarsa dan dipalsukan", secret: secret, fileID: fileID, expires: expires, userID: userID, signature: "x", now: time.Unix(expires+1, 0), wantErr: ErrLinkSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyFileLink(tt.secret, tt.fileID, tt.expires, tt.userID, tt.signature, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, ingin %v", err, tt.wantErr)
+			}
+		})
+	}
+}